@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"os"
 	"registration-app/config"
 	"registration-app/database"
 	routes "registration-app/internal/app/http"
+	"registration-app/internal/app/jobs"
+	"registration-app/internal/infra/storage"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -15,15 +18,17 @@ func main() {
 	// gin.SetMode(gin.ReleaseMode) uncomment only in production
 	config.LoadEnv()
 	database.InitDB()
+	storage.InitStorage()
+	jobs.Start(context.Background())
 
 	r := gin.Default()
 
 	// ✅ Add CORS middleware BEFORE registering routes
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{os.Getenv("CORS_ORIGIN")},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "If-Match"},
+		ExposeHeaders:    []string{"Content-Length", "ETag"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -0,0 +1,44 @@
+package main
+
+// Sweeps media.Image rows (and their files) that nothing references anymore.
+//
+//	go run ./cmd/gc-images -dry-run
+//	go run ./cmd/gc-images -grace 24h
+//
+// The API runs the same sweep hourly; this is for reports and manual cleanups.
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"registration-app/database"
+	"registration-app/internal/app/jobs"
+	"registration-app/internal/infra/storage"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report what would be deleted")
+	grace := flag.Duration("grace", jobs.ImageGCGracePeriod(), "how long an image must be unreferenced before deletion")
+	flag.Parse()
+
+	_ = godotenv.Load()
+	database.InitDB()
+	storage.InitStorage()
+
+	report, err := jobs.SweepOrphanImages(context.Background(), database.DB, storage.Store, jobs.ImageGCOptions{
+		GracePeriod: *grace,
+		DryRun:      *dryRun,
+	})
+	if err != nil {
+		log.Fatal("❌ image gc failed:", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(report)
+}
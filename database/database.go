@@ -36,6 +36,14 @@ func InitDB() {
 		log.Fatal("❌ Failed to enable pgcrypto extension:", err)
 	}
 
+	// custom join tables (extra term_id index) must be known before AutoMigrate
+	if err := DB.SetupJoinTable(&works.Artwork{}, "Terms", &works.ArtworkTerm{}); err != nil {
+		log.Fatal("❌ Join table setup error:", err)
+	}
+	if err := DB.SetupJoinTable(&works.Series{}, "Terms", &works.SeriesTerm{}); err != nil {
+		log.Fatal("❌ Join table setup error:", err)
+	}
+
 	// ✅ Auto-migrate all domain models
 	if err := DB.AutoMigrate(
 		// core
@@ -46,6 +54,7 @@ func InitDB() {
 
 		// media
 		&media.Image{},
+		&media.ImageVariant{},
 
 		// works (NEW)
 		&works.Series{},
@@ -54,14 +63,35 @@ func InitDB() {
 		&works.Artwork{},
 		&works.ArtworkRevision{},
 		&works.ArtworkI18nRevision{},
+		&works.ArtworkEdition{},
+		&works.ArtworkProvenanceRevision{},
+		&works.ArtworkProvenanceI18nRevision{},
+		&works.ArtworkExhibitionRevision{},
+		&works.ArtworkExhibitionI18nRevision{},
+		&works.ArtworkLiteratureRevision{},
+		&works.ArtworkLiteratureI18nRevision{},
+		&works.ArtworkCertificate{},
+		&works.Term{},
+		&works.TermI18n{},
+		&works.ArtworkTerm{},
+		&works.SeriesTerm{},
 
 		// site
 		&site.Template{},
 		&site.SitePage{},
+		&site.SitePageRevision{},
 		&site.SitePageBlock{},
 	); err != nil {
 		log.Fatal("❌ AutoMigrate error:", err)
 	}
 
+	if err := runDataMigrations(DB); err != nil {
+		log.Fatal("❌ Data migration error:", err)
+	}
+
+	if _, err := site.UpgradeBlocks(DB); err != nil {
+		log.Fatal("❌ Block upgrade error:", err)
+	}
+
 	fmt.Println("✅ Connected and migrated successfully")
 }
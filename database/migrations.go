@@ -0,0 +1,133 @@
+package database
+
+import (
+	"fmt"
+
+	"registration-app/internal/domain/site"
+	"registration-app/internal/domain/works"
+
+	"gorm.io/gorm"
+)
+
+// dataMigrations run after AutoMigrate on every start, so each one must be idempotent.
+// Use sql for plain statements, run for Go.
+var dataMigrations = []struct {
+	name string
+	sql  string
+	run  func(tx *gorm.DB) error
+}{
+	{
+		// revisions that were live before history tracking existed
+		name: "backfill artwork_revisions.published_at",
+		sql: `UPDATE artwork_revisions r SET published_at = r.updated_at
+			WHERE r.published_at IS NULL
+			  AND EXISTS (SELECT 1 FROM artworks a WHERE a.published_revision_id = r.id)`,
+	},
+	{
+		name: "backfill series_revisions.published_at",
+		sql: `UPDATE series_revisions r SET published_at = r.updated_at
+			WHERE r.published_at IS NULL
+			  AND EXISTS (SELECT 1 FROM series s WHERE s.published_revision_id = r.id)`,
+	},
+	{
+		name: "parse structured artwork revision fields",
+		run:  backfillStructuredArtworkFields,
+	},
+	{
+		name: "create works_ts_config function",
+		sql:  works.TextSearchConfigFunctionSQL(),
+	},
+	{
+		name: "index artwork_i18n_revisions for search",
+		sql:  `CREATE INDEX IF NOT EXISTS idx_artwork_i18n_revisions_search ON artwork_i18n_revisions USING GIN (` + works.ArtworkI18nSearchVector + `)`,
+	},
+	{
+		name: "index series_i18n_revisions for search",
+		sql:  `CREATE INDEX IF NOT EXISTS idx_series_i18n_revisions_search ON series_i18n_revisions USING GIN (` + works.SeriesI18nSearchVector + `)`,
+	},
+	{
+		name: "index artwork_revisions for search",
+		sql:  `CREATE INDEX IF NOT EXISTS idx_artwork_revisions_search ON artwork_revisions USING GIN (` + works.ArtworkFieldsSearchVector + `)`,
+	},
+	{
+		// blocks used to hang off the page directly and were live right away;
+		// seeds may still insert them that way
+		name: "move site page blocks into revisions",
+		run:  site.AdoptLegacyBlocks,
+	},
+	{
+		// image GC looks up blocks by {"imageId": ...} / {"imageIds": [...]} containment
+		name: "index site_page_blocks props",
+		sql:  `CREATE INDEX IF NOT EXISTS idx_site_page_blocks_props ON site_page_blocks USING GIN (props jsonb_path_ops)`,
+	},
+	{
+		// public sites resolve custom domains by LOWER(custom_domain)
+		name: "index users lower custom_domain",
+		sql:  `CREATE INDEX IF NOT EXISTS idx_users_custom_domain_lower ON users (LOWER(custom_domain))`,
+	},
+}
+
+func runDataMigrations(db *gorm.DB) error {
+	for _, m := range dataMigrations {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if m.sql != "" {
+				if err := tx.Exec(m.sql).Error; err != nil {
+					return err
+				}
+			}
+			if m.run != nil {
+				return m.run(tx)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+// backfillStructuredArtworkFields parses the free-text year/medium/size/price
+// of revisions whose structured counterpart is still empty; unparseable values
+// stay free text only. Filled columns are never touched again.
+func backfillStructuredArtworkFields(tx *gorm.DB) error {
+	var revs []works.ArtworkRevision
+	return tx.Select("id", "year", "medium", "size_cm", "price",
+		"year_from", "medium_key", "height", "width", "depth", "price_minor", "price_visibility").
+		Where(`(year <> '' AND year_from IS NULL)
+			OR (medium <> '' AND medium_key = '')
+			OR (size_cm <> '' AND height IS NULL AND width IS NULL AND depth IS NULL)
+			OR (price <> '' AND price_minor IS NULL AND price_visibility = ?)`, works.PriceShow).
+		FindInBatches(&revs, 500, func(batch *gorm.DB, _ int) error {
+			for _, r := range revs {
+				u := map[string]interface{}{}
+				if r.YearFrom == nil {
+					if from, to, ok := works.ParseYear(r.Year); ok {
+						u["year_from"], u["year_to"] = from, to
+					}
+				}
+				if r.MediumKey == "" {
+					if key := works.MatchMediumKey(r.Medium); key != "" {
+						u["medium_key"] = key
+					}
+				}
+				if r.Height == nil && r.Width == nil && r.Depth == nil {
+					if d, ok := works.ParseDimensions(r.SizeCM); ok {
+						u["height"], u["width"], u["depth"], u["dimension_unit"] = d.Height, d.Width, d.Depth, d.Unit
+					}
+				}
+				if r.PriceMinor == nil && r.PriceVisibility == works.PriceShow {
+					if p, ok := works.ParsePrice(r.Price); ok {
+						u["price_minor"], u["price_currency"], u["price_visibility"] = p.AmountMinor, p.Currency, p.Visibility
+					}
+				}
+				if len(u) == 0 {
+					continue
+				}
+				if err := tx.Model(&works.ArtworkRevision{}).Where("id = ?", r.ID).UpdateColumns(u).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
@@ -0,0 +1,131 @@
+package mediaapi
+
+import (
+	"errors"
+	"net/http"
+
+	"registration-app/database"
+	"registration-app/internal/domain/media"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ------------------------------
+// POST /media/images (multipart, field "file")
+// -> { id, original, mime_type, bytes }; reference it via ImageInput.id
+// ------------------------------
+func UploadImage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	// leave some room for the multipart envelope
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxUploadBytes()+(1<<20))
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if fh.Size > MaxUploadBytes() {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File too large"})
+		return
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer f.Close()
+
+	img, err := StoreImage(c.Request.Context(), database.DB, userID, f, fh.Filename)
+	if err != nil {
+		if status, msg, ok := ImageInputError(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store image", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"id":        img.ID,
+		"original":  img.OriginalPath,
+		"mime_type": img.MimeType,
+		"bytes":     img.Bytes,
+		"width":     img.Width,
+		"height":    img.Height,
+		"status":    img.Status,
+	})
+}
+
+// ------------------------------
+// GET /media/images/:id (owner only) -> image + variants, for polling status
+// ------------------------------
+func GetImage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var img media.Image
+	if err := database.DB.
+		Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("width ASC") }).
+		First(&img, "id = ? AND user_id = ?", c.Param("id"), userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load image"})
+		return
+	}
+
+	c.JSON(http.StatusOK, img)
+}
+
+// ------------------------------
+// POST /media/images/:id/reprocess (owner only)
+// Re-queues derivative generation; safe to call repeatedly.
+// ------------------------------
+func ReprocessImage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	res := database.DB.Model(&media.Image{}).
+		Where("id = ? AND user_id = ? AND storage_key IS NOT NULL", c.Param("id"), userID).
+		Updates(map[string]interface{}{
+			"status":              media.StatusPending,
+			"processing_attempts": 0,
+			"processing_error":    "",
+			"process_after":       nil,
+		})
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue image"})
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": media.StatusPending})
+}
+
+func mustUserID(c *gin.Context) (uint, bool) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, false
+	}
+	return userID, true
+}
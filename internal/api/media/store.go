@@ -0,0 +1,133 @@
+package mediaapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"registration-app/internal/domain/media"
+	"registration-app/internal/infra/imaging"
+	"registration-app/internal/infra/storage"
+
+	"gorm.io/gorm"
+)
+
+// allowed upload types (sniffed from content, not trusted from the client)
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+const defaultMaxUploadBytes int64 = 25 << 20 // Caddy allows 30MB bodies
+
+// MaxUploadBytes returns MAX_UPLOAD_BYTES or the default.
+func MaxUploadBytes() int64 {
+	if v, err := strconv.ParseInt(os.Getenv("MAX_UPLOAD_BYTES"), 10, 64); err == nil && v > 0 {
+		return v
+	}
+	return defaultMaxUploadBytes
+}
+
+// StoreImage validates and stores an original upload for userID and creates
+// its media.Image row (status pending, derivatives follow in the background).
+// The file is removed again if the row cannot be created.
+func StoreImage(ctx context.Context, db *gorm.DB, userID uint, r io.Reader, filename string) (*media.Image, error) {
+	max := MaxUploadBytes()
+	data, err := io.ReadAll(io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, err
+	}
+	mimeType, ext, cfg, err := sniffImage(data, max)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("users/%d/originals/%s%s", userID, randomHex(16), ext)
+	if err := storage.Store.Put(ctx, key, bytes.NewReader(data), mimeType); err != nil {
+		return nil, err
+	}
+
+	uid := userID
+	img := media.Image{
+		OriginalPath: storage.Store.URL(key),
+		UserID:       &uid,
+		StorageKey:   &key,
+		MimeType:     mimeType,
+		Bytes:        int64(len(data)),
+		OriginalName: filename,
+		Width:        cfg.Width,
+		Height:       cfg.Height,
+		Status:       media.StatusPending, // picked up by jobs.ProcessPendingImages
+	}
+	if err := db.Create(&img).Error; err != nil {
+		_ = storage.Store.Delete(ctx, key)
+		return nil, err
+	}
+
+	return &img, nil
+}
+
+// ValidateImage runs the checks of StoreImage on data without storing it.
+func ValidateImage(data []byte) error {
+	_, _, _, err := sniffImage(data, MaxUploadBytes())
+	return err
+}
+
+// sniffImage checks size and content type of an upload.
+func sniffImage(data []byte, max int64) (mimeType, ext string, cfg image.Config, err error) {
+	if int64(len(data)) > max {
+		return "", "", cfg, fmt.Errorf("file too large")
+	}
+	if len(data) == 0 {
+		return "", "", cfg, fmt.Errorf("empty file")
+	}
+
+	mimeType = http.DetectContentType(data)
+	ext, ok := allowedImageTypes[mimeType]
+	if !ok {
+		return "", "", cfg, fmt.Errorf("unsupported file type")
+	}
+	cfg, _, err = imaging.DecodeConfig(data)
+	if err != nil {
+		return "", "", cfg, fmt.Errorf("unsupported file type")
+	}
+	if err := imaging.CheckPixels(cfg); err != nil {
+		return "", "", cfg, err
+	}
+	return mimeType, ext, cfg, nil
+}
+
+// ImageInputError maps StoreImage errors caused by the upload itself to a
+// status and message; ok is false for server errors.
+func ImageInputError(err error) (status int, msg string, ok bool) {
+	switch {
+	case errors.Is(err, imaging.ErrTooManyPixels):
+		return http.StatusRequestEntityTooLarge, "Image dimensions too large", true
+	case errors.Is(err, imaging.ErrInvalidDimensions):
+		return http.StatusBadRequest, "Invalid image dimensions", true
+	}
+	switch err.Error() {
+	case "file too large":
+		return http.StatusRequestEntityTooLarge, "File too large", true
+	case "empty file":
+		return http.StatusBadRequest, "File is empty", true
+	case "unsupported file type":
+		return http.StatusUnsupportedMediaType, "Only JPEG, PNG and WebP images are allowed", true
+	}
+	return 0, "", false
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,439 @@
+package siteapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"registration-app/database"
+	"registration-app/internal/domain/site"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Site block editing (auth)
+	-------------------------
+	POST   /site/pages/:id/blocks            add a block (at sortIndex, default end)
+	PUT    /site/pages/:id/blocks/:blockId   update type and/or props
+	DELETE /site/pages/:id/blocks/:blockId   delete a block
+	PUT    /site/pages/:id/blocks/reorder    { blockIds } full ordered list
+	GET    /site/block-types                 registry for the editor
+
+	- all edits go to the draft revision (see revisions.go)
+	- sort_index is kept dense (0..n-1) after every change
+	- props are validated against the block type's schema whenever they or
+	  the type change
+*/
+
+var blockTypeRe = regexp.MustCompile(`^[a-z][a-zA-Z0-9_-]{0,63}$`)
+
+// compactBlock checks the shape of the block type and returns compacted props ("{}" when empty).
+func compactBlock(typ string, props json.RawMessage) (string, json.RawMessage, error) {
+	typ = strings.TrimSpace(typ)
+	if typ == "" {
+		return "", nil, fmt.Errorf("block type required")
+	}
+	if !blockTypeRe.MatchString(typ) {
+		return "", nil, fmt.Errorf("block type invalid")
+	}
+
+	trimmed := bytes.TrimSpace(props)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return typ, json.RawMessage("{}"), nil
+	}
+	if trimmed[0] != '{' {
+		return "", nil, fmt.Errorf("block props must be a JSON object")
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, trimmed); err != nil {
+		return "", nil, fmt.Errorf("block props must be a JSON object")
+	}
+	return typ, json.RawMessage(buf.Bytes()), nil
+}
+
+// normalizeBlock is compactBlock plus schema validation; it returns the
+// schema version the props are stored with.
+func normalizeBlock(typ string, props json.RawMessage) (string, json.RawMessage, int, error) {
+	typ, props, err := compactBlock(typ, props)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	if err := site.ValidateBlockProps(typ, props); err != nil {
+		return "", nil, 0, err
+	}
+	bt, _ := site.LookupBlockType(typ)
+	return typ, props, bt.Version, nil
+}
+
+// sameProps compares props semantically; jsonb does not keep key order or spacing.
+func sameProps(a, b json.RawMessage) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func revisionBlocks(tx *gorm.DB, revisionID string) ([]site.SitePageBlock, error) {
+	var blocks []site.SitePageBlock
+	err := orderBlocks(tx.Where("revision_id = ?", revisionID)).Find(&blocks).Error
+	return blocks, err
+}
+
+// resequenceBlocks writes sort_index = position for every block that moved.
+func resequenceBlocks(tx *gorm.DB, blocks []site.SitePageBlock) error {
+	for i := range blocks {
+		if blocks[i].SortIndex == i {
+			continue
+		}
+		if err := tx.Model(&site.SitePageBlock{}).
+			Where("id = ?", blocks[i].ID).
+			Update("sort_index", i).Error; err != nil {
+			return err
+		}
+		blocks[i].SortIndex = i
+	}
+	return nil
+}
+
+// replacePageBlocks makes the draft's blocks exactly the given ordered list:
+// known ids (after mapping through ids) are updated, empty ids created,
+// everything else deleted.
+func replacePageBlocks(tx *gorm.DB, dr *site.SitePageRevision, ids map[string]string, in []BlockInput) error {
+	if len(in) > site.MaxBlocksPerPage {
+		return fmt.Errorf("too many blocks")
+	}
+
+	existing := dr.Blocks
+	byID := make(map[string]site.SitePageBlock, len(existing))
+	for _, b := range existing {
+		byID[b.ID] = b
+	}
+
+	type planned struct {
+		id      string
+		typ     string
+		props   json.RawMessage
+		version int
+	}
+	plan := make([]planned, 0, len(in))
+	keep := map[string]bool{}
+	for _, b := range in {
+		typ, props, err := compactBlock(b.Type, b.Props)
+		if err != nil {
+			return err
+		}
+		version := 0
+		id := ""
+		if b.ID != "" {
+			id = draftBlockID(ids, b.ID)
+			old, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("unknown block")
+			}
+			if keep[id] {
+				return fmt.Errorf("duplicate block")
+			}
+			keep[id] = true
+			// untouched blocks are not re-validated (e.g. legacy types)
+			if old.Type == typ && sameProps(old.Props, props) {
+				version = old.SchemaVersion
+			}
+		}
+		if version == 0 {
+			if typ, props, version, err = normalizeBlock(typ, props); err != nil {
+				return err
+			}
+		}
+		plan = append(plan, planned{id: id, typ: typ, props: props, version: version})
+	}
+
+	var drop []string
+	for _, b := range existing {
+		if !keep[b.ID] {
+			drop = append(drop, b.ID)
+		}
+	}
+	if len(drop) > 0 {
+		if err := tx.Where("id IN ?", drop).Delete(&site.SitePageBlock{}).Error; err != nil {
+			return err
+		}
+	}
+
+	for i, p := range plan {
+		if p.id == "" {
+			nb := site.SitePageBlock{PageID: dr.PageID, RevisionID: &dr.ID, SortIndex: i, Type: p.typ, Props: p.props, SchemaVersion: p.version}
+			if err := tx.Create(&nb).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		old := byID[p.id]
+		if old.SortIndex == i && old.Type == p.typ && sameProps(old.Props, p.props) {
+			continue
+		}
+		if err := tx.Model(&site.SitePageBlock{}).
+			Where("id = ?", p.id).
+			Updates(map[string]interface{}{"sort_index": i, "type": p.typ, "props": p.props, "schema_version": p.version}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ------------------------------
+// POST /site/pages/:id/blocks
+// ------------------------------
+func CreateSiteBlock(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	var created site.SitePageBlock
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		typ, props, version, err := normalizeBlock(req.Type, req.Props)
+		if err != nil {
+			return err
+		}
+
+		dr, _, err := ensureDraftPageRevision(tx, &p)
+		if err != nil {
+			return err
+		}
+		blocks := dr.Blocks
+		if len(blocks) >= site.MaxBlocksPerPage {
+			return fmt.Errorf("too many blocks")
+		}
+		pos := len(blocks)
+		if req.SortIndex != nil && *req.SortIndex >= 0 && *req.SortIndex < pos {
+			pos = *req.SortIndex
+		}
+
+		created = site.SitePageBlock{PageID: p.ID, RevisionID: &dr.ID, SortIndex: pos, Type: typ, Props: props, SchemaVersion: version}
+		if err := tx.Create(&created).Error; err != nil {
+			return err
+		}
+
+		ordered := make([]site.SitePageBlock, 0, len(blocks)+1)
+		ordered = append(ordered, blocks[:pos]...)
+		ordered = append(ordered, created)
+		ordered = append(ordered, blocks[pos:]...)
+		if err := resequenceBlocks(tx, ordered); err != nil {
+			return err
+		}
+		return touchPage(tx, p.ID)
+	})
+	if err != nil {
+		respondPageError(c, err, "create block")
+		return
+	}
+
+	c.JSON(201, toBlockDTO(created))
+}
+
+// ------------------------------
+// PUT /site/pages/:id/blocks/:blockId
+// ------------------------------
+func UpdateSiteBlock(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	var b site.SitePageBlock
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		dr, ids, err := ensureDraftPageRevision(tx, &p)
+		if err != nil {
+			return err
+		}
+		blockID := draftBlockID(ids, c.Param("blockId"))
+		if err := tx.Where("id = ? AND revision_id = ?", blockID, dr.ID).Take(&b).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("block not found")
+			}
+			return err
+		}
+
+		typ := b.Type
+		if req.Type != nil {
+			typ = *req.Type
+		}
+		props, version := b.Props, b.SchemaVersion
+		if req.Props != nil {
+			props = req.Props
+		} else if props, version, err = site.UpgradeBlockProps(b.Type, b.SchemaVersion, b.Props); err != nil {
+			return err
+		}
+		// stored props of an unchanged type are kept as they are
+		if req.Props != nil || typ != b.Type {
+			if typ, props, version, err = normalizeBlock(typ, props); err != nil {
+				return err
+			}
+		}
+
+		b.Type, b.Props, b.SchemaVersion = typ, props, version
+		if err := tx.Model(&site.SitePageBlock{}).
+			Where("id = ?", b.ID).
+			Updates(map[string]interface{}{"type": typ, "props": props, "schema_version": version}).Error; err != nil {
+			return err
+		}
+		return touchPage(tx, p.ID)
+	})
+	if err != nil {
+		respondPageError(c, err, "update block")
+		return
+	}
+
+	c.JSON(200, toBlockDTO(b))
+}
+
+// ------------------------------
+// DELETE /site/pages/:id/blocks/:blockId
+// ------------------------------
+func DeleteSiteBlock(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		dr, ids, err := ensureDraftPageRevision(tx, &p)
+		if err != nil {
+			return err
+		}
+		blockID := draftBlockID(ids, c.Param("blockId"))
+		res := tx.Where("id = ? AND revision_id = ?", blockID, dr.ID).Delete(&site.SitePageBlock{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("block not found")
+		}
+
+		blocks, err := revisionBlocks(tx, dr.ID)
+		if err != nil {
+			return err
+		}
+		if err := resequenceBlocks(tx, blocks); err != nil {
+			return err
+		}
+		return touchPage(tx, p.ID)
+	})
+	if err != nil {
+		respondPageError(c, err, "delete block")
+		return
+	}
+
+	c.JSON(200, gin.H{"status": "deleted"})
+}
+
+// ------------------------------
+// PUT /site/pages/:id/blocks/reorder
+// ------------------------------
+func ReorderSiteBlocks(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ReorderBlocksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	pageID := c.Param("id")
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, pageID)
+		if err != nil {
+			return err
+		}
+		dr, ids, err := ensureDraftPageRevision(tx, &p)
+		if err != nil {
+			return err
+		}
+		blocks := dr.Blocks
+
+		// the list must name every block of the page exactly once
+		if len(req.BlockIDs) != len(blocks) {
+			return fmt.Errorf("invalid block ids")
+		}
+		byID := make(map[string]site.SitePageBlock, len(blocks))
+		for _, b := range blocks {
+			byID[b.ID] = b
+		}
+		ordered := make([]site.SitePageBlock, 0, len(blocks))
+		for _, id := range req.BlockIDs {
+			id = draftBlockID(ids, id)
+			b, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("invalid block ids")
+			}
+			delete(byID, id)
+			ordered = append(ordered, b)
+		}
+
+		if err := resequenceBlocks(tx, ordered); err != nil {
+			return err
+		}
+		return touchPage(tx, p.ID)
+	})
+	if err != nil {
+		respondPageError(c, err, "reorder blocks")
+		return
+	}
+
+	respondPage(c, 200, pageID)
+}
+
+// ------------------------------
+// GET /site/block-types
+// ------------------------------
+func ListBlockTypes(c *gin.Context) {
+	types := site.BlockTypes()
+	out := GetBlockTypesResponse{BlockTypes: make([]BlockTypeDTO, 0, len(types))}
+	for _, bt := range types {
+		var schema bytes.Buffer
+		if err := json.Compact(&schema, bt.Schema); err != nil {
+			c.JSON(500, gin.H{"error": "Failed to load block types"})
+			return
+		}
+		out.BlockTypes = append(out.BlockTypes, BlockTypeDTO{
+			Type:    bt.Type,
+			Label:   bt.Label,
+			Version: bt.Version,
+			Schema:  schema.Bytes(),
+		})
+	}
+	c.JSON(200, out)
+}
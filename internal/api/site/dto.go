@@ -1,6 +1,12 @@
 package siteapi
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+
+	worksapi "registration-app/internal/api/works"
+	"registration-app/internal/domain/site"
+)
 
 type TemplateDTO struct {
 	ID   string `json:"id"`
@@ -13,6 +19,8 @@ type BlockDTO struct {
 	Type      string          `json:"type"`
 	SortIndex int             `json:"sortIndex"`
 	Props     json.RawMessage `json:"props"`
+
+	SchemaVersion int `json:"schemaVersion,omitempty"`
 }
 
 type PageDTO struct {
@@ -21,6 +29,9 @@ type PageDTO struct {
 	Lang   string     `json:"lang"`
 	Status string     `json:"status"`
 	Blocks []BlockDTO `json:"blocks"`
+
+	HasDraft    bool       `json:"hasDraft,omitempty"` // unpublished changes exist
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
 }
 
 type GetTemplatesResponse struct {
@@ -35,3 +46,135 @@ type GetTemplateResponse struct {
 type GetUserSiteResponse struct {
 	Pages []PageDTO `json:"pages"`
 }
+
+// ---------- editing
+
+type CreatePageRequest struct {
+	Slug string `json:"slug" binding:"required"`
+	Lang string `json:"lang" binding:"required"`
+}
+
+// UpdatePageRequest changes page attributes, which apply immediately;
+// content goes live through POST /site/pages/:id/publish.
+type UpdatePageRequest struct {
+	Slug *string `json:"slug"` // renames every language of the page
+}
+
+type AddPageLanguageRequest struct {
+	Lang       string `json:"lang" binding:"required"`
+	CopyBlocks bool   `json:"copyBlocks"` // start from this page's blocks
+}
+
+type BlockInput struct {
+	ID    string          `json:"id,omitempty"` // empty = new block
+	Type  string          `json:"type"`
+	Props json.RawMessage `json:"props"`
+}
+
+// SavePageRequest replaces the draft in one go: Blocks is the full ordered list,
+// draft blocks missing from it are deleted.
+type SavePageRequest struct {
+	Slug   *string      `json:"slug"`
+	Blocks []BlockInput `json:"blocks" binding:"required"`
+}
+
+type CreateBlockRequest struct {
+	Type      string          `json:"type" binding:"required"`
+	Props     json.RawMessage `json:"props"`
+	SortIndex *int            `json:"sortIndex"` // insert position, default end
+}
+
+type UpdateBlockRequest struct {
+	Type  *string         `json:"type"`
+	Props json.RawMessage `json:"props"` // replaces the props when set
+}
+
+type ReorderBlocksRequest struct {
+	BlockIDs []string `json:"blockIds" binding:"required"` // ordered list
+}
+
+func toBlockDTO(b site.SitePageBlock) BlockDTO {
+	return BlockDTO{
+		ID:        b.ID,
+		Type:      b.Type,
+		SortIndex: b.SortIndex,
+		Props:     b.Props,
+
+		SchemaVersion: b.SchemaVersion,
+	}
+}
+
+// toPageDTO renders p with the blocks of rev (nil = no content yet).
+func toPageDTO(p site.SitePage, rev *site.SitePageRevision) PageDTO {
+	out := PageDTO{
+		ID:       p.ID,
+		Slug:     p.Slug,
+		Lang:     p.Lang,
+		Status:   p.Status,
+		Blocks:   []BlockDTO{},
+		HasDraft: p.DraftRevisionID != nil && (p.PublishedRevisionID == nil || *p.DraftRevisionID != *p.PublishedRevisionID),
+	}
+	if p.PublishedRevision != nil {
+		out.PublishedAt = p.PublishedRevision.PublishedAt
+	}
+	if rev != nil {
+		for _, b := range rev.Blocks {
+			out.Blocks = append(out.Blocks, toBlockDTO(b))
+		}
+	}
+	return out
+}
+
+type BlockTypeDTO struct {
+	Type    string          `json:"type"`
+	Label   string          `json:"label"`
+	Version int             `json:"version"`
+	Schema  json.RawMessage `json:"schema"`
+}
+
+type GetBlockTypesResponse struct {
+	BlockTypes []BlockTypeDTO `json:"blockTypes"`
+}
+
+type PageRevisionDTO struct {
+	ID          string     `json:"id"`
+	IsDraft     bool       `json:"isDraft"`
+	IsPublished bool       `json:"isPublished"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	Blocks      int        `json:"blocks"`
+}
+
+type PageRevisionListDTO struct {
+	Revisions []PageRevisionDTO `json:"revisions"`
+}
+
+// ---------- public delivery
+
+type PublicPageRefDTO struct {
+	Slug  string   `json:"slug"`
+	Langs []string `json:"langs"`
+}
+
+type PublicSiteDTO struct {
+	Slug     string             `json:"slug"`
+	Artist   string             `json:"artist"`
+	URL      string             `json:"url"`
+	Mode     string             `json:"mode"` // full | limited
+	NoIndex  bool               `json:"noindex"`
+	Branding bool               `json:"branding"` // show platform branding
+	Langs    []string           `json:"langs"`
+	Pages    []PublicPageRefDTO `json:"pages"`
+}
+
+type PublicPageResponse struct {
+	Site   PublicSiteDTO                    `json:"site"`
+	Page   PageDTO                          `json:"page"`
+	Images map[string]*worksapi.ImageRefDTO `json:"images"` // imageId -> variants
+}
+
+type PublicWorksResponse struct {
+	Site   PublicSiteDTO              `json:"site"`
+	Series []worksapi.PublicSeriesDTO `json:"series"`
+}
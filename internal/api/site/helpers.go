@@ -41,8 +41,7 @@ func GetSiteTemplate(c *gin.Context) {
 	}
 
 	var pages []site.SitePage
-	if err := templatePagesQuery(database.DB, tmpl.ID).
-		Preload("Blocks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_index ASC") }).
+	if err := withPageRevisions(templatePagesQuery(database.DB, tmpl.ID)).
 		Order("slug ASC, lang ASC").
 		Find(&pages).Error; err != nil {
 		c.JSON(500, gin.H{"error": "Failed to load template pages"})
@@ -55,20 +54,8 @@ func GetSiteTemplate(c *gin.Context) {
 	}
 
 	for _, p := range pages {
-		dto := PageDTO{
-			Slug:   p.Slug,
-			Lang:   p.Lang,
-			Status: p.Status,
-			Blocks: make([]BlockDTO, 0, len(p.Blocks)),
-		}
-		for _, b := range p.Blocks {
-			dto.Blocks = append(dto.Blocks, BlockDTO{
-				ID:        b.ID,
-				Type:      b.Type,
-				SortIndex: b.SortIndex,
-				Props:     b.Props, // ✅ already json.RawMessage
-			})
-		}
+		dto := toPageDTO(p, pageLiveView(p))
+		dto.ID, dto.HasDraft, dto.PublishedAt = "", false, nil
 		resp.Pages = append(resp.Pages, dto)
 	}
 
@@ -83,8 +70,7 @@ func GetUserSite(c *gin.Context) {
 	}
 
 	var pages []site.SitePage
-	if err := userPagesQuery(database.DB, userID).
-		Preload("Blocks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_index ASC") }).
+	if err := withPageRevisions(userPagesQuery(database.DB, userID)).
 		Order("slug ASC, lang ASC").
 		Find(&pages).Error; err != nil {
 		c.JSON(500, gin.H{"error": "Failed to load site"})
@@ -93,22 +79,7 @@ func GetUserSite(c *gin.Context) {
 
 	out := GetUserSiteResponse{Pages: make([]PageDTO, 0, len(pages))}
 	for _, p := range pages {
-		page := PageDTO{
-			ID:     p.ID,
-			Slug:   p.Slug,
-			Lang:   p.Lang,
-			Status: p.Status,
-			Blocks: make([]BlockDTO, 0, len(p.Blocks)),
-		}
-		for _, b := range p.Blocks {
-			page.Blocks = append(page.Blocks, BlockDTO{
-				ID:        b.ID,
-				Type:      b.Type,
-				SortIndex: b.SortIndex,
-				Props:     b.Props,
-			})
-		}
-		out.Pages = append(out.Pages, page)
+		out.Pages = append(out.Pages, toPageDTO(p, pageDraftView(p)))
 	}
 
 	c.JSON(200, out)
@@ -134,9 +105,16 @@ func CopySiteFromTemplate(c *gin.Context) {
 	}
 
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		// template blocks seeded since the last start have no revision (and
+		// maybe old props) yet
+		if err := site.AdoptLegacyBlocks(tx); err != nil {
+			return err
+		}
+		if _, err := site.UpgradeBlocks(tx); err != nil {
+			return err
+		}
 		var tPages []site.SitePage
-		if err := templatePagesQuery(tx, tmpl.ID).
-			Preload("Blocks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_index ASC") }).
+		if err := withPageRevisions(templatePagesQuery(tx, tmpl.ID)).
 			Find(&tPages).Error; err != nil {
 			return err
 		}
@@ -161,24 +139,22 @@ func CopySiteFromTemplate(c *gin.Context) {
 				UserID:    &uid,
 				Slug:      tp.Slug,
 				Lang:      tp.Lang,
-				Status:    "draft",
+				Status:    site.PageDraft,
+
+				TemplateID: &tmpl.ID, // picks the theme of the rendered site
 			}
 			if err := tx.Create(&up).Error; err != nil {
 				return err
 			}
 			createdPages++
 
-			for _, tb := range tp.Blocks {
-				ub := site.SitePageBlock{
-					PageID:    up.ID,
-					SortIndex: tb.SortIndex,
-					Type:      tb.Type,
-					Props:     tb.Props,
-				}
-				if err := tx.Create(&ub).Error; err != nil {
+			// template content lands in the user's draft
+			if rev := pageLiveView(tp); rev != nil {
+				dr, _, err := newPageDraftFrom(tx, &up, rev)
+				if err != nil {
 					return err
 				}
-				createdBlocks++
+				createdBlocks += len(dr.Blocks)
 			}
 		}
 
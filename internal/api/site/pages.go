@@ -0,0 +1,324 @@
+package siteapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/site"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/*
+	Site page editing (auth)
+	------------------------
+	POST   /site/pages                 create a page (slug + lang), starts as draft
+	PATCH  /site/pages/:id             rename slug (all languages)
+	PUT    /site/pages/:id             batch save: slug and the full block list of the draft
+	DELETE /site/pages/:id             delete one language of a page
+	POST   /site/pages/:id/languages   add a language, optionally copying the blocks
+
+	- a "page" is all SitePage rows of a user sharing a slug, one row per lang
+	- every lookup goes through userPagesQuery; other users' pages are 404
+	- writes lock the page row so concurrent saves serialize
+	- responses show the draft view (see revisions.go)
+*/
+
+// isPageInputError reports validation errors that map to 400.
+func isPageInputError(err error) bool {
+	msg := err.Error()
+	switch msg {
+	case "slug required", "invalid slug", "lang required", "invalid lang",
+		"too many blocks", "duplicate block", "unknown block", "invalid block ids":
+		return true
+	}
+	return strings.HasPrefix(msg, "block type") || strings.HasPrefix(msg, "block props")
+}
+
+// respondPageError maps the errors of the editing endpoints.
+func respondPageError(c *gin.Context, err error, action string) {
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		c.JSON(404, gin.H{"error": "Page not found"})
+	case err.Error() == "block not found":
+		c.JSON(404, gin.H{"error": "Block not found"})
+	case err.Error() == "page exists":
+		c.JSON(409, gin.H{"error": "A page with this slug and language already exists"})
+	case err.Error() == "slug taken":
+		c.JSON(409, gin.H{"error": "Slug already used by another page"})
+	case isPageInputError(err):
+		c.JSON(400, gin.H{"error": err.Error()})
+	default:
+		c.JSON(500, gin.H{"error": "Failed to " + action, "details": err.Error()})
+	}
+}
+
+// lockUserPage loads one of the user's pages FOR UPDATE.
+func lockUserPage(tx *gorm.DB, userID uint, pageID string) (site.SitePage, error) {
+	var p site.SitePage
+	err := userPagesQuery(tx, userID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", pageID).
+		First(&p).Error
+	return p, err
+}
+
+func pageExists(tx *gorm.DB, userID uint, slug, lang string) (bool, error) {
+	var n int64
+	err := userPagesQuery(tx, userID).Where("slug = ? AND lang = ?", slug, lang).Count(&n).Error
+	return n > 0, err
+}
+
+// touchPage bumps updated_at after block changes.
+func touchPage(tx *gorm.DB, pageID string) error {
+	return tx.Model(&site.SitePage{}).Where("id = ?", pageID).Update("updated_at", time.Now()).Error
+}
+
+// renamePage renames the slug of every language of p (nil = keep).
+func renamePage(tx *gorm.DB, userID uint, p *site.SitePage, slug *string) error {
+	if slug == nil {
+		return nil
+	}
+	newSlug, err := site.NormalizePageSlug(*slug)
+	if err != nil {
+		return err
+	}
+	if newSlug == p.Slug {
+		return nil
+	}
+	var n int64
+	if err := userPagesQuery(tx, userID).Where("slug = ?", newSlug).Count(&n).Error; err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("slug taken")
+	}
+	if err := userPagesQuery(tx, userID).
+		Where("slug = ?", p.Slug).
+		Updates(map[string]interface{}{"slug": newSlug, "updated_at": time.Now()}).Error; err != nil {
+		return err
+	}
+	p.Slug = newSlug
+	return nil
+}
+
+// ------------------------------
+// POST /site/pages
+// ------------------------------
+func CreateSitePage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreatePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	var page site.SitePage
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		slug, err := site.NormalizePageSlug(req.Slug)
+		if err != nil {
+			return err
+		}
+		lang, err := site.NormalizeLang(req.Lang)
+		if err != nil {
+			return err
+		}
+		exists, err := pageExists(tx, userID, slug, lang)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("page exists")
+		}
+
+		uid := userID
+		page = site.SitePage{OwnerType: site.OwnerUser, UserID: &uid, Slug: slug, Lang: lang, Status: site.PageDraft}
+		return tx.Create(&page).Error
+	})
+	if err != nil {
+		respondPageError(c, err, "create page")
+		return
+	}
+
+	c.JSON(201, toPageDTO(page, nil))
+}
+
+// ------------------------------
+// PATCH /site/pages/:id
+// ------------------------------
+func UpdateSitePage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdatePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	pageID := c.Param("id")
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, pageID)
+		if err != nil {
+			return err
+		}
+		return renamePage(tx, userID, &p, req.Slug)
+	})
+	if err != nil {
+		respondPageError(c, err, "update page")
+		return
+	}
+
+	respondPage(c, 200, pageID)
+}
+
+// ------------------------------
+// PUT /site/pages/:id (batch save)
+// ------------------------------
+func SaveSitePage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var req SavePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	pageID := c.Param("id")
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, pageID)
+		if err != nil {
+			return err
+		}
+		if err := renamePage(tx, userID, &p, req.Slug); err != nil {
+			return err
+		}
+		dr, ids, err := ensureDraftPageRevision(tx, &p)
+		if err != nil {
+			return err
+		}
+		if err := replacePageBlocks(tx, dr, ids, req.Blocks); err != nil {
+			return err
+		}
+		return touchPage(tx, p.ID)
+	})
+	if err != nil {
+		respondPageError(c, err, "save page")
+		return
+	}
+
+	respondPage(c, 200, pageID)
+}
+
+// ------------------------------
+// DELETE /site/pages/:id
+// ------------------------------
+func DeleteSitePage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	pageID := c.Param("id")
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, pageID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("page_id = ?", p.ID).Delete(&site.SitePageBlock{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&site.SitePage{}, "id = ?", p.ID).Error; err != nil {
+			return err
+		}
+		// after the page: its revision pointers reference these rows
+		return tx.Where("page_id = ?", p.ID).Delete(&site.SitePageRevision{}).Error
+	})
+	if err != nil {
+		respondPageError(c, err, "delete page")
+		return
+	}
+
+	c.JSON(200, gin.H{"status": "deleted"})
+}
+
+// ------------------------------
+// POST /site/pages/:id/languages
+// ------------------------------
+func AddSitePageLanguage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var req AddPageLanguageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	var created site.SitePage
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		src, err := lockUserPage(tx, userID, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		if err := withPageRevisions(tx).First(&src, "id = ?", src.ID).Error; err != nil {
+			return err
+		}
+		lang, err := site.NormalizeLang(req.Lang)
+		if err != nil {
+			return err
+		}
+		exists, err := pageExists(tx, userID, src.Slug, lang)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("page exists")
+		}
+
+		uid := userID
+		created = site.SitePage{
+			OwnerType:  site.OwnerUser,
+			UserID:     &uid,
+			TemplateID: src.TemplateID,
+			Slug:       src.Slug,
+			Lang:       lang,
+			Status:     site.PageDraft,
+		}
+		if err := tx.Create(&created).Error; err != nil {
+			return err
+		}
+		if !req.CopyBlocks || pageDraftView(src) == nil {
+			return nil
+		}
+
+		// the new language starts from what the editor currently shows
+		dr, _, err := newPageDraftFrom(tx, &created, pageDraftView(src))
+		if err != nil {
+			return err
+		}
+		created.DraftRevision = dr
+		return nil
+	})
+	if err != nil {
+		respondPageError(c, err, "add language")
+		return
+	}
+
+	c.JSON(201, toPageDTO(created, created.DraftRevision))
+}
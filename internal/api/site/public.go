@@ -0,0 +1,364 @@
+package siteapi
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"registration-app/database"
+	worksapi "registration-app/internal/api/works"
+	"registration-app/internal/domain/access"
+	"registration-app/internal/domain/site"
+	"registration-app/internal/domain/users"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Public site delivery
+	--------------------
+	GET /public/site                        tenant from Host (subdomain or custom domain)
+	GET /public/site/pages/:page?lang=de
+	GET /public/site/works
+	GET /public/sites/:site                 same, tenant from an explicit slug
+	GET /public/sites/:site/pages/:page?lang=de
+	GET /public/sites/:site/works
+
+	- published content only: page revisions and works without a published
+	  revision do not exist for visitors
+	- limited public mode (access.PublicModeFromState) applies site.LimitedRules:
+	  max artworks, gallery blocks dropped, noindex, platform branding
+	- custom domains only resolve while the plan allows them
+	- hosts of the API and the editor app (API_HOST, APP_URL, CORS_ORIGIN) never
+	  resolve to a site, even when a slug or custom domain matches them
+*/
+
+type publicTenant struct {
+	User   users.User
+	Slug   string
+	Mode   access.PublicMode
+	Limits *site.LimitedRules // nil = full site
+}
+
+// requestHost is the host the visitor asked for (first X-Forwarded-Host behind a proxy).
+func requestHost(c *gin.Context) string {
+	host := c.Request.Host
+	if fwd := c.GetHeader("X-Forwarded-Host"); fwd != "" {
+		host = strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+	return site.NormalizeHost(host)
+}
+
+// isAppHost reports whether host is the API's own host (API_HOST, comma
+// separated, hosts or URLs) or the editor app's (APP_URL, CORS_ORIGIN).
+func isAppHost(host string) bool {
+	if host == "" {
+		return false
+	}
+	for _, env := range []string{"API_HOST", "APP_URL", "CORS_ORIGIN"} {
+		for _, v := range strings.Split(os.Getenv(env), ",") {
+			v = strings.TrimSpace(v)
+			if strings.Contains(v, "://") {
+				u, err := url.Parse(v)
+				if err != nil {
+					continue
+				}
+				v = u.Host
+			}
+			if v != "" && site.NormalizeHost(v) == host {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupTenant finds the site owner by slug, or by host when slug is empty:
+// platform subdomains first, then custom domains the plan allows. App hosts
+// (isAppHost) never resolve. Returns gorm.ErrRecordNotFound for unknown sites.
+func lookupTenant(slug, host string) (*publicTenant, error) {
+	var u users.User
+	var err error
+
+	if slug == "" {
+		if isAppHost(host) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		slug, _ = site.SlugFromHost(host)
+	}
+	if slug != "" {
+		err = database.DB.Preload("Plan").First(&u, "site_slug = ?", slug).Error
+	} else if host != "" {
+		hosts := []string{host}
+		if bare := strings.TrimPrefix(host, "www."); bare != host {
+			hosts = append(hosts, bare)
+		} else {
+			hosts = append(hosts, "www."+host)
+		}
+		err = database.DB.Preload("Plan").First(&u, "LOWER(custom_domain) IN ?", hosts).Error
+		if err == nil && !access.CustomDomainAllowed(access.ComputeEffectiveAccessState(time.Now(), u), u.Plan) {
+			err = gorm.ErrRecordNotFound
+		}
+	} else {
+		err = gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if u.SiteSlug == nil || *u.SiteSlug == "" {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	state := access.ComputeEffectiveAccessState(time.Now(), u)
+	return &publicTenant{
+		User:   u,
+		Slug:   *u.SiteSlug,
+		Mode:   access.PublicModeFromState(state, u.Plan),
+		Limits: access.PublicLimitsFor(state, u.Plan),
+	}, nil
+}
+
+// setPublicHeaders applies noindex and the short public cache.
+func setPublicHeaders(c *gin.Context, t *publicTenant) {
+	if t.Limits != nil && t.Limits.NoIndex {
+		c.Header("X-Robots-Tag", "noindex")
+	}
+	c.Header("Cache-Control", "public, max-age=60")
+}
+
+// resolveTenant finds the site owner from :site or the request host and writes
+// the error response itself when it returns false.
+func resolveTenant(c *gin.Context) (*publicTenant, bool) {
+	slug := strings.ToLower(strings.TrimSpace(c.Param("site")))
+	host := ""
+	if slug == "" {
+		host = requestHost(c)
+	}
+
+	t, err := lookupTenant(slug, host)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(404, gin.H{"error": "Site not found"})
+			return nil, false
+		}
+		c.JSON(500, gin.H{"error": "Failed to load site", "details": err.Error()})
+		return nil, false
+	}
+
+	setPublicHeaders(c, t)
+	return t, true
+}
+
+func publishedPagesQuery(db *gorm.DB, userID uint) *gorm.DB {
+	return userPagesQuery(db, userID).Where("published_revision_id IS NOT NULL")
+}
+
+func buildPublicSite(t *publicTenant) (PublicSiteDTO, error) {
+	out := PublicSiteDTO{
+		Slug:   t.Slug,
+		Artist: strings.TrimSpace(t.User.Name + " " + t.User.Lastname),
+		URL:    site.BuildPublicURL(t.Slug),
+		Mode:   string(t.Mode),
+		Langs:  []string{},
+		Pages:  []PublicPageRefDTO{},
+	}
+	if t.Limits != nil {
+		out.NoIndex = t.Limits.NoIndex
+		out.Branding = t.Limits.ShowPlatformBranding
+	}
+
+	var pages []site.SitePage
+	if err := publishedPagesQuery(database.DB, t.User.ID).
+		Select("slug", "lang").
+		Order("slug ASC, lang ASC").
+		Find(&pages).Error; err != nil {
+		return out, err
+	}
+
+	langs := map[string]bool{}
+	for _, p := range pages {
+		if n := len(out.Pages); n == 0 || out.Pages[n-1].Slug != p.Slug {
+			out.Pages = append(out.Pages, PublicPageRefDTO{Slug: p.Slug, Langs: []string{}})
+		}
+		ref := &out.Pages[len(out.Pages)-1]
+		ref.Langs = append(ref.Langs, p.Lang)
+		if !langs[p.Lang] {
+			langs[p.Lang] = true
+			out.Langs = append(out.Langs, p.Lang)
+		}
+	}
+	sort.Strings(out.Langs)
+	return out, nil
+}
+
+// pickPageLang prefers the exact language, then the same base language
+// ("de" for "de-at" and the other way round), then the first page.
+func pickPageLang(pages []site.SitePage, want string) *site.SitePage {
+	if len(pages) == 0 {
+		return nil
+	}
+	if want != "" {
+		for i := range pages {
+			if pages[i].Lang == want {
+				return &pages[i]
+			}
+		}
+		base := strings.SplitN(want, "-", 2)[0]
+		for i := range pages {
+			if strings.SplitN(pages[i].Lang, "-", 2)[0] == base {
+				return &pages[i]
+			}
+		}
+	}
+	return &pages[0]
+}
+
+// loadPublicPage returns the published blocks of page slug in the best matching
+// language, with gallery blocks dropped on limited sites.
+// Returns gorm.ErrRecordNotFound when no language of the page is published.
+func loadPublicPage(t *publicTenant, slug, lang string) (PageDTO, error) {
+	var pages []site.SitePage
+	if err := publishedPagesQuery(database.DB, t.User.ID).
+		Preload("PublishedRevision.Blocks", orderBlocks).
+		Where("slug = ?", slug).
+		Order("lang ASC").
+		Find(&pages).Error; err != nil {
+		return PageDTO{}, err
+	}
+
+	p := pickPageLang(pages, lang)
+	if p == nil || p.PublishedRevision == nil {
+		return PageDTO{}, gorm.ErrRecordNotFound
+	}
+
+	page := toPageDTO(*p, p.PublishedRevision)
+	page.ID, page.HasDraft = "", false
+	if t.Limits != nil && t.Limits.HideGalleries {
+		kept := make([]BlockDTO, 0, len(page.Blocks))
+		for _, b := range page.Blocks {
+			if b.Type != "gallery" {
+				kept = append(kept, b)
+			}
+		}
+		page.Blocks = kept
+	}
+	return page, nil
+}
+
+// blockImageIDs collects imageId / imageIds from block props.
+func blockImageIDs(blocks []BlockDTO) []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, b := range blocks {
+		var props struct {
+			ImageID  *string  `json:"imageId"`
+			ImageIDs []string `json:"imageIds"`
+		}
+		if err := json.Unmarshal(b.Props, &props); err != nil {
+			continue
+		}
+		if props.ImageID != nil {
+			add(*props.ImageID)
+		}
+		for _, id := range props.ImageIDs {
+			add(id)
+		}
+	}
+	return ids
+}
+
+// ------------------------------
+// GET /public/site
+// GET /public/sites/:site
+func GetPublicSite(c *gin.Context) {
+	t, ok := resolveTenant(c)
+	if !ok {
+		return
+	}
+
+	out, err := buildPublicSite(t)
+	if err != nil {
+		c.JSON(500, gin.H{"error": "Failed to load site", "details": err.Error()})
+		return
+	}
+	c.JSON(200, out)
+}
+
+// ------------------------------
+// GET /public/site/pages/:page
+// GET /public/sites/:site/pages/:page
+func GetPublicSitePage(c *gin.Context) {
+	t, ok := resolveTenant(c)
+	if !ok {
+		return
+	}
+
+	slug, err := site.NormalizePageSlug(c.Param("page"))
+	if err != nil {
+		c.JSON(404, gin.H{"error": "Page not found"})
+		return
+	}
+	lang, _ := site.NormalizeLang(c.Query("lang"))
+
+	page, err := loadPublicPage(t, slug, lang)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(404, gin.H{"error": "Page not found"})
+			return
+		}
+		c.JSON(500, gin.H{"error": "Failed to load page", "details": err.Error()})
+		return
+	}
+
+	siteDTO, err := buildPublicSite(t)
+	if err != nil {
+		c.JSON(500, gin.H{"error": "Failed to load site", "details": err.Error()})
+		return
+	}
+
+	images, err := worksapi.LoadPublicImages(database.DB, t.User.ID, blockImageIDs(page.Blocks))
+	if err != nil {
+		c.JSON(500, gin.H{"error": "Failed to load images", "details": err.Error()})
+		return
+	}
+
+	c.JSON(200, PublicPageResponse{Site: siteDTO, Page: page, Images: images})
+}
+
+// ------------------------------
+// GET /public/site/works
+// GET /public/sites/:site/works
+func GetPublicSiteWorks(c *gin.Context) {
+	t, ok := resolveTenant(c)
+	if !ok {
+		return
+	}
+
+	siteDTO, err := buildPublicSite(t)
+	if err != nil {
+		c.JSON(500, gin.H{"error": "Failed to load site", "details": err.Error()})
+		return
+	}
+
+	max := 0
+	if t.Limits != nil {
+		max = t.Limits.MaxArtworks
+	}
+	series, err := worksapi.LoadPublicWorks(database.DB, t.User.ID, max)
+	if err != nil {
+		c.JSON(500, gin.H{"error": "Failed to load works", "details": err.Error()})
+		return
+	}
+
+	c.JSON(200, PublicWorksResponse{Site: siteDTO, Series: series})
+}
@@ -0,0 +1,626 @@
+package siteapi
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"registration-app/database"
+	worksapi "registration-app/internal/api/works"
+	"registration-app/internal/domain/site"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Public site HTML (server-side rendering)
+	----------------------------------------
+	GET /             home page ("home", else "index", else the first page)
+	GET /:page?lang=  any published page
+	GET /robots.txt
+
+	- served on site hosts only (platform subdomain or custom domain, see
+	  PublicSiteHTML); every other request falls through to the API routes
+	- same content rules as the JSON delivery in public.go
+	- themes/<template slug>/ holds the templates of a site template, on top of
+	  themes/shared/ (one partial per block type, <picture>); a theme overrides
+	  a shared partial by defining a template with the same name. Sites without
+	  a template (or with one that has no theme) use themes/default/
+*/
+
+//go:embed themes
+var themeFS embed.FS
+
+const defaultTheme = "default"
+
+var themes = loadThemes()
+
+func loadThemes() map[string]*template.Template {
+	out := map[string]*template.Template{}
+	dirs, err := fs.ReadDir(themeFS, "themes")
+	if err != nil {
+		log.Fatalf("site themes: %v", err)
+	}
+	for _, d := range dirs {
+		if !d.IsDir() || d.Name() == "shared" {
+			continue
+		}
+		t, err := template.New(d.Name()).
+			Funcs(renderFuncs).
+			ParseFS(themeFS, "themes/shared/*.html", "themes/"+d.Name()+"/*.html")
+		if err != nil {
+			log.Fatalf("site theme %s: %v", d.Name(), err)
+		}
+		out[d.Name()] = t
+	}
+	if out[defaultTheme] == nil {
+		log.Fatalf("site themes: %q missing", defaultTheme)
+	}
+	return out
+}
+
+// ---------- view model
+
+type renderMeta struct {
+	Title       string
+	Description string
+	Image       string
+	Canonical   string
+	NoIndex     bool
+	Alternates  []renderAlternate
+}
+
+type renderAlternate struct {
+	Lang string
+	URL  string
+}
+
+type renderNavItem struct {
+	Label  string
+	URL    string
+	Active bool
+}
+
+type renderBlock struct {
+	Type  string
+	Props map[string]interface{}
+	Lang  string
+
+	Images   map[string]*worksapi.ImageRefDTO
+	Series   []worksapi.PublicSeriesDTO  // series-grid
+	Artworks []worksapi.PublicArtworkDTO // gallery
+}
+
+type renderView struct {
+	Site   PublicSiteDTO
+	Lang   string
+	Meta   renderMeta
+	Nav    []renderNavItem
+	Blocks []renderBlock
+	Year   int
+
+	NotFound bool
+}
+
+// ---------- template helpers
+
+type pictureSource struct {
+	Type   string
+	Srcset string
+}
+
+type artworkArgs struct {
+	Artwork worksapi.PublicArtworkDTO
+	Lang    string
+}
+
+type pictureArgs struct {
+	Image *worksapi.ImageRefDTO
+	Alt   string
+	Sizes string
+}
+
+var renderFuncs = template.FuncMap{
+	// picture packs the arguments of the "picture" partial
+	"picture": func(img *worksapi.ImageRefDTO, alt, sizes string) pictureArgs {
+		return pictureArgs{Image: img, Alt: alt, Sizes: sizes}
+	},
+	"artworkOf": func(a worksapi.PublicArtworkDTO, lang string) artworkArgs {
+		return artworkArgs{Artwork: a, Lang: lang}
+	},
+	"sources":    pictureSources,
+	"baseDomain": site.BaseDomain,
+	"image": func(images map[string]*worksapi.ImageRefDTO, id interface{}) *worksapi.ImageRefDTO {
+		return images[fmt.Sprint(id)]
+	},
+	// urlImage wraps static {"webp", "avif"} URLs (hero imageUrls)
+	"urlImage": func(v interface{}) *worksapi.ImageRefDTO {
+		urls, _ := v.(map[string]interface{})
+		webp, _ := urls["webp"].(string)
+		avif, _ := urls["avif"].(string)
+		if webp == "" && avif == "" {
+			return nil
+		}
+		original := webp
+		if original == "" {
+			original = avif
+		}
+		return &worksapi.ImageRefDTO{Original: original, Webp: webp, Avif: avif}
+	},
+	"tr":         translate,
+	"paragraphs": paragraphs,
+	"lines":      func(s string) []string { return strings.Split(s, "\n") },
+	"str":        func(v interface{}) string { s, _ := v.(string); return s },
+	"list":       func(v interface{}) []interface{} { l, _ := v.([]interface{}); return l },
+	"int": func(v interface{}, def int) int {
+		if f, ok := v.(float64); ok {
+			return int(f)
+		}
+		return def
+	},
+}
+
+// pictureSources groups the variants of img into one <source> per format,
+// modern formats first; the <img> fallback uses the original.
+func pictureSources(img *worksapi.ImageRefDTO) []pictureSource {
+	if img == nil {
+		return nil
+	}
+	byType := map[string][]string{}
+	for _, s := range img.Srcset {
+		byType[s.Type] = append(byType[s.Type], fmt.Sprintf("%s %dw", s.URL, s.Width))
+	}
+	if len(byType) == 0 {
+		// not processed into variants (yet): single-size conversions
+		if img.Avif != "" {
+			byType["image/avif"] = []string{img.Avif}
+		}
+		if img.Webp != "" {
+			byType["image/webp"] = []string{img.Webp}
+		}
+	}
+
+	out := []pictureSource{}
+	for _, typ := range []string{"image/avif", "image/webp", "image/jpeg", "image/png"} {
+		if set, ok := byType[typ]; ok {
+			out = append(out, pictureSource{Type: typ, Srcset: strings.Join(set, ", ")})
+			delete(byType, typ)
+		}
+	}
+	for typ, set := range byType {
+		out = append(out, pictureSource{Type: typ, Srcset: strings.Join(set, ", ")})
+	}
+	return out
+}
+
+// translate picks key from an i18n map: lang, then its base language, then any.
+func translate(i18n map[string]map[string]string, lang, key string) string {
+	if v := i18n[lang][key]; v != "" {
+		return v
+	}
+	if v := i18n[strings.SplitN(lang, "-", 2)[0]][key]; v != "" {
+		return v
+	}
+	for _, t := range i18n {
+		if v := t[key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// paragraphs splits plain text on blank lines.
+func paragraphs(s string) []string {
+	out := []string{}
+	for _, p := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// ---------- serving
+
+// PublicSiteHTML serves "/", "/:page" and "/robots.txt" of public sites as HTML
+// when the request host belongs to one. Other requests (and hosts that are not
+// a site, like the API's own) continue to the API routes.
+func PublicSiteHTML() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != "GET" && c.Request.Method != "HEAD" {
+			c.Next()
+			return
+		}
+
+		path := c.Request.URL.Path
+		pageSlug := ""
+		switch {
+		case path == "/" || path == "/robots.txt":
+		default:
+			s, err := site.NormalizePageSlug(strings.TrimPrefix(path, "/"))
+			if err != nil || "/"+s != path {
+				c.Next()
+				return
+			}
+			pageSlug = s
+		}
+
+		host := requestHost(c)
+		if !maybeSiteHost(host) {
+			c.Next()
+			return
+		}
+		t, err := lookupTenant("", host)
+		if err != nil {
+			if err != gorm.ErrRecordNotFound {
+				log.Printf("public site %s: %v", host, err)
+			}
+			c.Next()
+			return
+		}
+
+		setPublicHeaders(c, t)
+		if path == "/robots.txt" {
+			robots := "User-agent: *\nAllow: /\n"
+			if t.Limits != nil && t.Limits.NoIndex {
+				robots = "User-agent: *\nDisallow: /\n"
+			}
+			c.Data(200, "text/plain; charset=utf-8", []byte(robots))
+			c.Abort()
+			return
+		}
+
+		status, body, err := renderPublicPage(c, t, pageSlug)
+		if err != nil {
+			log.Printf("public site %s%s: %v", host, path, err)
+			c.Data(500, "text/plain; charset=utf-8", []byte("Internal Server Error"))
+			c.Abort()
+			return
+		}
+		c.Data(status, "text/html; charset=utf-8", body)
+		c.Abort()
+	}
+}
+
+// maybeSiteHost skips the tenant lookup for hosts that can only be the API or
+// the editor app (localhost, IPs, isAppHost, the platform domain itself).
+// Set API_HOST in production: API requests on it then never touch the database.
+func maybeSiteHost(host string) bool {
+	if host == "" || host == "localhost" || net.ParseIP(host) != nil || isAppHost(host) {
+		return false
+	}
+	if _, ok := site.SlugFromHost(host); ok {
+		return true
+	}
+	base := site.BaseDomain()
+	return host != base && !strings.HasSuffix(host, "."+base)
+}
+
+// renderPublicPage renders pageSlug ("" = home page) of t.
+func renderPublicPage(c *gin.Context, t *publicTenant, pageSlug string) (int, []byte, error) {
+	siteDTO, err := buildPublicSite(t)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	theme, err := siteTheme(t.User.ID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	home := homePageSlug(siteDTO)
+	if pageSlug == "" {
+		pageSlug = home
+	}
+	lang, _ := site.NormalizeLang(c.Query("lang"))
+
+	view := renderView{Site: siteDTO, Lang: lang, Year: time.Now().Year()}
+	origin := requestOrigin(c)
+
+	page, err := loadPublicPage(t, pageSlug, lang)
+	if err == gorm.ErrRecordNotFound || pageSlug == "" {
+		view.NotFound = true
+		if view.Lang == "" && len(siteDTO.Langs) > 0 {
+			view.Lang = siteDTO.Langs[0]
+		}
+		view.Meta = renderMeta{Title: "Page not found · " + siteDTO.Artist, NoIndex: true}
+		view.Nav = siteNav(siteDTO, home, "", lang)
+		body, err := executeTheme(theme, view)
+		return 404, body, err
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	view.Lang = page.Lang
+
+	if view.Blocks, err = buildRenderBlocks(t, page); err != nil {
+		return 0, nil, err
+	}
+	view.Nav = siteNav(siteDTO, home, pageSlug, lang)
+	view.Meta = pageMeta(siteDTO, page, view.Blocks, origin, pageSlug == home)
+	if t.Limits != nil && t.Limits.NoIndex {
+		view.Meta.NoIndex = true
+	}
+
+	body, err := executeTheme(theme, view)
+	return 200, body, err
+}
+
+func executeTheme(theme *template.Template, view renderView) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := theme.ExecuteTemplate(&buf, "layout", view); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// siteTheme returns the theme of the template the user's pages were copied from.
+func siteTheme(userID uint) (*template.Template, error) {
+	var slugs []string
+	pages := database.DB.Model(&site.SitePage{}).
+		Select("template_id").
+		Where("owner_type = ? AND user_id = ?", site.OwnerUser, userID)
+	if err := database.DB.Model(&site.Template{}).
+		Where("id IN (?)", pages).
+		Limit(1).
+		Pluck("slug", &slugs).Error; err != nil {
+		return nil, err
+	}
+	if len(slugs) > 0 {
+		if t, ok := themes[slugs[0]]; ok {
+			return t, nil
+		}
+	}
+	return themes[defaultTheme], nil
+}
+
+func homePageSlug(s PublicSiteDTO) string {
+	for _, want := range []string{"home", "index"} {
+		for _, p := range s.Pages {
+			if p.Slug == want {
+				return want
+			}
+		}
+	}
+	if len(s.Pages) > 0 {
+		return s.Pages[0].Slug
+	}
+	return ""
+}
+
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if p := c.GetHeader("X-Forwarded-Proto"); p != "" {
+		scheme = strings.TrimSpace(strings.Split(p, ",")[0])
+	}
+	return scheme + "://" + requestHost(c)
+}
+
+// pageURL is the site-relative URL of a page; lang is kept when chosen explicitly.
+func pageURL(slug, home, lang string) string {
+	u := "/"
+	if slug != home {
+		u += slug
+	}
+	if lang != "" {
+		u += "?lang=" + url.QueryEscape(lang)
+	}
+	return u
+}
+
+func siteNav(s PublicSiteDTO, home, active, lang string) []renderNavItem {
+	out := make([]renderNavItem, 0, len(s.Pages))
+	for _, p := range s.Pages {
+		label := strings.ReplaceAll(p.Slug, "-", " ")
+		if p.Slug == home {
+			label = "home"
+		}
+		out = append(out, renderNavItem{
+			Label:  strings.ToUpper(label[:1]) + label[1:],
+			URL:    pageURL(p.Slug, home, lang),
+			Active: p.Slug == active,
+		})
+	}
+	// home first, the rest keep slug order
+	for i := range out {
+		if s.Pages[i].Slug == home && i > 0 {
+			item := out[i]
+			copy(out[1:i+1], out[:i])
+			out[0] = item
+			break
+		}
+	}
+	return out
+}
+
+// buildRenderBlocks decodes props and attaches the images and works blocks refer to.
+func buildRenderBlocks(t *publicTenant, page PageDTO) ([]renderBlock, error) {
+	images, err := worksapi.LoadPublicImages(database.DB, t.User.ID, blockImageIDs(page.Blocks))
+	if err != nil {
+		return nil, err
+	}
+
+	var series []worksapi.PublicSeriesDTO
+	needWorks := false
+	for _, b := range page.Blocks {
+		if b.Type == "series-grid" || b.Type == "gallery" {
+			needWorks = true
+		}
+	}
+	if needWorks {
+		max := 0
+		if t.Limits != nil {
+			max = t.Limits.MaxArtworks
+		}
+		if series, err = worksapi.LoadPublicWorks(database.DB, t.User.ID, max); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]renderBlock, 0, len(page.Blocks))
+	for _, b := range page.Blocks {
+		rb := renderBlock{Type: b.Type, Props: map[string]interface{}{}, Lang: page.Lang, Images: images}
+		if err := json.Unmarshal(b.Props, &rb.Props); err != nil {
+			continue
+		}
+
+		switch b.Type {
+		case "series-grid":
+			ids := stringList(rb.Props["seriesIds"])
+			if len(ids) == 0 {
+				rb.Series = series
+				break
+			}
+			byID := map[string]worksapi.PublicSeriesDTO{}
+			for _, s := range series {
+				byID[s.ID] = s
+			}
+			for _, id := range ids {
+				if s, ok := byID[id]; ok {
+					rb.Series = append(rb.Series, s)
+				}
+			}
+		case "gallery":
+			byID := map[string]worksapi.PublicArtworkDTO{}
+			for _, s := range series {
+				for _, a := range s.Items {
+					byID[a.ID] = a
+				}
+			}
+			for _, id := range stringList(rb.Props["artworkIds"]) {
+				if a, ok := byID[id]; ok {
+					rb.Artworks = append(rb.Artworks, a)
+				}
+			}
+		}
+		out = append(out, rb)
+	}
+	return out, nil
+}
+
+func stringList(v interface{}) []string {
+	l, _ := v.([]interface{})
+	out := make([]string, 0, len(l))
+	for _, x := range l {
+		if s, ok := x.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// pageMeta derives title, description and preview image from the blocks:
+// the first hero (or text) wins, the first image of the page is the preview.
+func pageMeta(s PublicSiteDTO, page PageDTO, blocks []renderBlock, origin string, isHome bool) renderMeta {
+	m := renderMeta{}
+	var img *worksapi.ImageRefDTO
+
+	for _, b := range blocks {
+		title, _ := b.Props["title"].(string)
+		switch b.Type {
+		case "hero":
+			if m.Title == "" {
+				m.Title = title
+			}
+			if sub, _ := b.Props["subtitle"].(string); m.Description == "" {
+				m.Description = sub
+			}
+		case "text":
+			if m.Title == "" {
+				m.Title = title
+			}
+			if body, _ := b.Props["body"].(string); m.Description == "" {
+				m.Description = body
+			}
+		}
+
+		if img == nil {
+			if id, ok := b.Props["imageId"].(string); ok {
+				img = b.Images[id]
+			}
+			if ids := stringList(b.Props["imageIds"]); img == nil && len(ids) > 0 {
+				img = b.Images[ids[0]]
+			}
+			for _, a := range b.Artworks {
+				if img == nil {
+					img = a.Image
+				}
+			}
+			for _, sr := range b.Series {
+				if img == nil {
+					img = sr.Image
+				}
+			}
+		}
+	}
+
+	switch {
+	case m.Title == "" && isHome:
+		m.Title = s.Artist
+	case m.Title == "":
+		m.Title = strings.ReplaceAll(page.Slug, "-", " ")
+		m.Title = strings.ToUpper(m.Title[:1]) + m.Title[1:] + " · " + s.Artist
+	case m.Title != s.Artist:
+		m.Title += " · " + s.Artist
+	}
+	m.Description = summary(m.Description, 160)
+
+	if img != nil {
+		// link preview scrapers rarely take webp/avif: widest jpeg/png, else the original
+		src := img.Original
+		for _, v := range img.Srcset {
+			if v.Type == "image/jpeg" || v.Type == "image/png" {
+				src = v.URL
+			}
+		}
+		m.Image = absURL(origin, src)
+	}
+
+	home := homePageSlug(s)
+	m.Canonical = absURL(origin, pageURL(page.Slug, home, ""))
+	for _, p := range s.Pages {
+		if p.Slug != page.Slug || len(p.Langs) < 2 {
+			continue
+		}
+		for _, l := range p.Langs {
+			m.Alternates = append(m.Alternates, renderAlternate{Lang: l, URL: absURL(origin, pageURL(page.Slug, home, l))})
+		}
+		if page.Lang != p.Langs[0] {
+			m.Canonical = absURL(origin, pageURL(page.Slug, home, page.Lang))
+		}
+	}
+	return m
+}
+
+// summary collapses whitespace and cuts s to max runes on a word boundary.
+func summary(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	r := []rune(s)[:max-1]
+	if i := strings.LastIndex(string(r), " "); i > max/2 {
+		return string(r)[:i] + "…"
+	}
+	return string(r) + "…"
+}
+
+func absURL(origin, u string) string {
+	if strings.HasPrefix(u, "/") && !strings.HasPrefix(u, "//") {
+		return origin + u
+	}
+	return u
+}
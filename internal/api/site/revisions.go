@@ -0,0 +1,415 @@
+package siteapi
+
+import (
+	"fmt"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/site"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Site page revisions (auth)
+	--------------------------
+	POST /site/pages/:id/publish                  draft -> published
+	POST /site/pages/:id/unpublish                take the page offline, content stays as draft
+	POST /site/pages/:id/discard                  drop the draft, editor falls back to published
+	GET  /site/pages/:id/revisions                newest first
+	POST /site/pages/:id/revisions/:rev/restore   copy a revision into a new draft
+	                                              (replaces and deletes an unpublished draft)
+
+	- same model as works.Series: block edits go to the draft revision (created
+	  from published on first edit), published revisions are immutable history
+	- cloning a revision gives its blocks new ids; edit handlers map ids of the
+	  revision they cloned from, so the first edit after publishing can still
+	  address blocks by the ids the editor loaded
+	- slug and language are page attributes and apply immediately
+*/
+
+func orderBlocks(db *gorm.DB) *gorm.DB { return db.Order("sort_index ASC, created_at ASC") }
+
+// withPageRevisions preloads both revision pointers with their blocks.
+func withPageRevisions(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("DraftRevision.Blocks", orderBlocks).
+		Preload("PublishedRevision.Blocks", orderBlocks)
+}
+
+// pageDraftView is what the editor shows: draft, falling back to published.
+func pageDraftView(p site.SitePage) *site.SitePageRevision {
+	if p.DraftRevision != nil {
+		return p.DraftRevision
+	}
+	return p.PublishedRevision
+}
+
+// pageLiveView is what visitors (and template copies) get: published, falling back to draft.
+func pageLiveView(p site.SitePage) *site.SitePageRevision {
+	if p.PublishedRevision != nil {
+		return p.PublishedRevision
+	}
+	return p.DraftRevision
+}
+
+// ensureDraftPageRevision returns the editable draft of p, cloning it first when
+// missing or already published. ids maps block ids of the clone source to the
+// new draft blocks (nil when no clone happened).
+func ensureDraftPageRevision(tx *gorm.DB, p *site.SitePage) (*site.SitePageRevision, map[string]string, error) {
+	// 1) editable draft already exists
+	if p.DraftRevisionID != nil && *p.DraftRevisionID != "" {
+		var dr site.SitePageRevision
+		if err := tx.Preload("Blocks", orderBlocks).First(&dr, "id = ?", *p.DraftRevisionID).Error; err != nil {
+			return nil, nil, err
+		}
+		if dr.PublishedAt == nil {
+			return &dr, nil, nil
+		}
+		return newPageDraftFrom(tx, p, &dr)
+	}
+
+	// 2) clone from published if available
+	if p.PublishedRevisionID != nil && *p.PublishedRevisionID != "" {
+		var pr site.SitePageRevision
+		if err := tx.Preload("Blocks", orderBlocks).First(&pr, "id = ?", *p.PublishedRevisionID).Error; err != nil {
+			return nil, nil, err
+		}
+		return newPageDraftFrom(tx, p, &pr)
+	}
+
+	// 3) nothing yet: empty draft
+	return newPageDraftFrom(tx, p, nil)
+}
+
+// newPageDraftFrom clones base (nil = empty) and points the page draft at the copy.
+func newPageDraftFrom(tx *gorm.DB, p *site.SitePage, base *site.SitePageRevision) (*site.SitePageRevision, map[string]string, error) {
+	dr, ids, err := clonePageRevision(tx, base, p.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Model(&site.SitePage{}).
+		Where("id = ?", p.ID).
+		Update("draft_revision_id", dr.ID).Error; err != nil {
+		return nil, nil, err
+	}
+	p.DraftRevisionID = &dr.ID
+
+	return dr, ids, nil
+}
+
+// dropPageDraft detaches the draft of p. Unpublished drafts are not history:
+// they are deleted with their blocks.
+func dropPageDraft(tx *gorm.DB, p *site.SitePage) error {
+	if p.DraftRevisionID == nil {
+		return nil
+	}
+	draftID := *p.DraftRevisionID
+	if err := tx.Model(&site.SitePage{}).Where("id = ?", p.ID).Update("draft_revision_id", nil).Error; err != nil {
+		return err
+	}
+	p.DraftRevisionID = nil
+
+	var dr site.SitePageRevision
+	if err := tx.Select("id", "published_at").First(&dr, "id = ?", draftID).Error; err != nil {
+		return err
+	}
+	if dr.PublishedAt != nil {
+		return nil
+	}
+	if err := tx.Where("revision_id = ?", dr.ID).Delete(&site.SitePageBlock{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&site.SitePageRevision{}, "id = ?", dr.ID).Error
+}
+
+// clonePageRevision copies the blocks of src into a new unpublished revision of
+// pageID. src nil creates an empty revision.
+func clonePageRevision(tx *gorm.DB, src *site.SitePageRevision, pageID string) (*site.SitePageRevision, map[string]string, error) {
+	dr := site.SitePageRevision{PageID: pageID}
+	if err := tx.Create(&dr).Error; err != nil {
+		return nil, nil, err
+	}
+
+	ids := map[string]string{}
+	if src != nil {
+		for i, b := range src.Blocks {
+			nb := site.SitePageBlock{
+				PageID:        pageID,
+				RevisionID:    &dr.ID,
+				SortIndex:     i,
+				Type:          b.Type,
+				Props:         b.Props,
+				SchemaVersion: b.SchemaVersion,
+			}
+			if err := tx.Create(&nb).Error; err != nil {
+				return nil, nil, err
+			}
+			ids[b.ID] = nb.ID
+			dr.Blocks = append(dr.Blocks, nb)
+		}
+	}
+
+	return &dr, ids, nil
+}
+
+// draftBlockID maps a block id the editor loaded to the current draft.
+func draftBlockID(ids map[string]string, id string) string {
+	if mapped, ok := ids[id]; ok {
+		return mapped
+	}
+	return id
+}
+
+// publishPageDraft makes the current draft (created from published if missing)
+// the published revision. The previous published revision stays as history.
+func publishPageDraft(tx *gorm.DB, p *site.SitePage) (*site.SitePageRevision, error) {
+	dr, _, err := ensureDraftPageRevision(tx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := tx.Model(&site.SitePageRevision{}).
+		Where("id = ?", dr.ID).
+		Update("published_at", now).Error; err != nil {
+		return nil, err
+	}
+	dr.PublishedAt = &now
+
+	if err := tx.Model(&site.SitePage{}).
+		Where("id = ?", p.ID).
+		Updates(map[string]interface{}{
+			"published_revision_id": dr.ID,
+			"draft_revision_id":     nil,
+			"status":                site.PagePublished,
+		}).Error; err != nil {
+		return nil, err
+	}
+	p.PublishedRevisionID = &dr.ID
+	p.DraftRevisionID = nil
+	p.Status = site.PagePublished
+
+	return dr, nil
+}
+
+// unpublishPage removes the published pointer. Without a draft the content is
+// kept as draft (the revision itself stays immutable history).
+func unpublishPage(tx *gorm.DB, p *site.SitePage) error {
+	updates := map[string]interface{}{
+		"published_revision_id": nil,
+		"status":                site.PageDraft,
+	}
+	if p.DraftRevisionID == nil && p.PublishedRevisionID != nil {
+		updates["draft_revision_id"] = p.PublishedRevisionID
+		p.DraftRevisionID = p.PublishedRevisionID
+	}
+	p.PublishedRevisionID = nil
+	p.Status = site.PageDraft
+
+	return tx.Model(&site.SitePage{}).Where("id = ?", p.ID).Updates(updates).Error
+}
+
+// respondPage writes the draft view of pageID.
+func respondPage(c *gin.Context, status int, pageID string) {
+	var p site.SitePage
+	if err := withPageRevisions(database.DB).First(&p, "id = ?", pageID).Error; err != nil {
+		c.JSON(500, gin.H{"error": "Failed to load page"})
+		return
+	}
+	c.JSON(status, toPageDTO(p, pageDraftView(p)))
+}
+
+// ------------------------------
+// POST /site/pages/:id/publish
+// ------------------------------
+func PublishSitePage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	pageID := c.Param("id")
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, pageID)
+		if err != nil {
+			return err
+		}
+		_, err = publishPageDraft(tx, &p)
+		return err
+	})
+	if err != nil {
+		respondPageError(c, err, "publish page")
+		return
+	}
+
+	respondPage(c, 200, pageID)
+}
+
+// ------------------------------
+// POST /site/pages/:id/unpublish
+// ------------------------------
+func UnpublishSitePage(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	pageID := c.Param("id")
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, pageID)
+		if err != nil {
+			return err
+		}
+		return unpublishPage(tx, &p)
+	})
+	if err != nil {
+		respondPageError(c, err, "unpublish page")
+		return
+	}
+
+	respondPage(c, 200, pageID)
+}
+
+// ------------------------------
+// POST /site/pages/:id/discard
+// Drops the draft so the editor falls back to published. Pages that were never
+// published keep their draft (discarding would wipe the content).
+// ------------------------------
+func DiscardSitePageDraft(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	pageID := c.Param("id")
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, pageID)
+		if err != nil {
+			return err
+		}
+		if p.PublishedRevisionID == nil {
+			return fmt.Errorf("not published")
+		}
+		if p.DraftRevisionID == nil || *p.DraftRevisionID == *p.PublishedRevisionID {
+			return nil // nothing to discard
+		}
+
+		return dropPageDraft(tx, &p)
+	})
+	if err != nil {
+		if err.Error() == "not published" {
+			c.JSON(409, gin.H{"error": "Page was never published, nothing to fall back to"})
+			return
+		}
+		respondPageError(c, err, "discard draft")
+		return
+	}
+
+	respondPage(c, 200, pageID)
+}
+
+// ------------------------------
+// GET /site/pages/:id/revisions (newest first)
+// ------------------------------
+func ListSitePageRevisions(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var p site.SitePage
+	if err := userPagesQuery(database.DB, userID).First(&p, "id = ?", c.Param("id")).Error; err != nil {
+		respondPageError(c, err, "load page")
+		return
+	}
+
+	var revs []site.SitePageRevision
+	if err := database.DB.
+		Where("page_id = ?", p.ID).
+		Order("created_at DESC").
+		Find(&revs).Error; err != nil {
+		c.JSON(500, gin.H{"error": "Failed to load revisions"})
+		return
+	}
+
+	var counts []struct {
+		RevisionID string
+		N          int
+	}
+	if err := database.DB.Model(&site.SitePageBlock{}).
+		Select("revision_id, COUNT(*) AS n").
+		Where("page_id = ? AND revision_id IS NOT NULL", p.ID).
+		Group("revision_id").
+		Scan(&counts).Error; err != nil {
+		c.JSON(500, gin.H{"error": "Failed to load revisions"})
+		return
+	}
+	blocks := make(map[string]int, len(counts))
+	for _, n := range counts {
+		blocks[n.RevisionID] = n.N
+	}
+
+	out := PageRevisionListDTO{Revisions: make([]PageRevisionDTO, 0, len(revs))}
+	for _, r := range revs {
+		out.Revisions = append(out.Revisions, PageRevisionDTO{
+			ID:          r.ID,
+			IsDraft:     p.DraftRevisionID != nil && *p.DraftRevisionID == r.ID,
+			IsPublished: p.PublishedRevisionID != nil && *p.PublishedRevisionID == r.ID,
+			PublishedAt: r.PublishedAt,
+			CreatedAt:   r.CreatedAt,
+			UpdatedAt:   r.UpdatedAt,
+			Blocks:      blocks[r.ID],
+		})
+	}
+	c.JSON(200, out)
+}
+
+// ------------------------------
+// POST /site/pages/:id/revisions/:rev/restore
+// Copies the revision into a new draft; published state is untouched.
+// ------------------------------
+func RestoreSitePageRevision(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	pageID := c.Param("id")
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		p, err := lockUserPage(tx, userID, pageID)
+		if err != nil {
+			return err
+		}
+
+		var rev site.SitePageRevision
+		if err := tx.Preload("Blocks", orderBlocks).
+			First(&rev, "id = ? AND page_id = ?", c.Param("rev"), p.ID).Error; err != nil {
+			return err
+		}
+		if p.DraftRevisionID != nil && *p.DraftRevisionID == rev.ID {
+			return nil // already the draft
+		}
+
+		// the restored copy replaces the current draft
+		if err := dropPageDraft(tx, &p); err != nil {
+			return err
+		}
+		if _, _, err := newPageDraftFrom(tx, &p, &rev); err != nil {
+			return err
+		}
+		return touchPage(tx, p.ID)
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(404, gin.H{"error": "Page or revision not found"})
+			return
+		}
+		respondPageError(c, err, "restore revision")
+		return
+	}
+
+	respondPage(c, 200, pageID)
+}
@@ -84,11 +84,8 @@ func BuildAccessSiteDTO(user users.User, policy access.Policy, limits *LimitsDTO
 	}
 
 	var customDomain *string
-	if policy.State != access.AccessLocked {
-		tier := plans.PlanTier(user.Plan)
-		if tier == "professional" || tier == "advanced" {
-			customDomain = user.CustomDomain
-		}
+	if access.CustomDomainAllowed(policy.State, user.Plan) {
+		customDomain = user.CustomDomain
 	}
 
 	return AccessSiteDTO{
@@ -0,0 +1,385 @@
+package works
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/users"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-pdf/fpdf"
+	qrcode "github.com/skip2/go-qrcode"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/*
+	Certificates of authenticity
+	----------------------------
+	POST /artworks/:id/certificate  { edition_id, lang, reissue } -> PDF
+	GET  /verify-certificate/:code  (public)
+
+	- issuing freezes title/year/medium/size/edition/artist of the published
+	  (else draft) revision and signs them with CERTIFICATE_SECRET (HMAC-SHA256),
+	  together with the image and language; without the secret both endpoints
+	  answer 503
+	- asking again returns the stored certificate unchanged; reissue=true revokes
+	  it and issues a new code from the current data
+	- multiples are certified per copy (edition_id required)
+	- the QR code points at CERTIFICATE_VERIFY_URL + code
+*/
+
+// certificateSecret returns CERTIFICATE_SECRET. It has no fallback: the key
+// must not be shared with anything else (e.g. JWT signing).
+func certificateSecret() ([]byte, error) {
+	v := os.Getenv("CERTIFICATE_SECRET")
+	if v == "" {
+		return nil, fmt.Errorf("CERTIFICATE_SECRET is not set")
+	}
+	return []byte(v), nil
+}
+
+// CertificateVerifyURL returns the public verification link of code.
+func CertificateVerifyURL(code string) string {
+	base := os.Getenv("CERTIFICATE_VERIFY_URL")
+	if base == "" {
+		base = strings.TrimRight(os.Getenv("APP_URL"), "/") + "/verify-certificate/"
+	}
+	return base + code
+}
+
+// newCertificateCode returns e.g. "COA-7KQ2-M9XD-4HTA" (60 random bits).
+func newCertificateCode() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	s := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)[:12]
+	return "COA-" + s[0:4] + "-" + s[4:8] + "-" + s[8:12]
+}
+
+type IssueCertificateRequest struct {
+	EditionID string `json:"edition_id"` // required for multiples
+	Lang      string `json:"lang"`       // printed language, default "en"
+	Reissue   bool   `json:"reissue"`    // revoke the active certificate and issue a new one
+}
+
+type CertificateVerificationDTO struct {
+	Code      string       `json:"code"`
+	Status    string       `json:"status"` // "valid" | "revoked" | "invalid" (signature mismatch)
+	Valid     bool         `json:"valid"`
+	Artist    string       `json:"artist"`
+	Title     string       `json:"title"`
+	Year      string       `json:"year,omitempty"`
+	Medium    string       `json:"medium,omitempty"`
+	Size      string       `json:"size,omitempty"`
+	Edition   string       `json:"edition,omitempty"`
+	Image     *ImageRefDTO `json:"image,omitempty"`
+	IssuedAt  time.Time    `json:"issuedAt"`
+	RevokedAt *time.Time   `json:"revokedAt,omitempty"`
+}
+
+// ------------------------------
+// POST /artworks/:id/certificate
+// ------------------------------
+func IssueArtworkCertificate(c *gin.Context) {
+	id := c.Param("id")
+
+	var req IssueCertificateRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Lang == "" {
+		req.Lang = "en"
+	}
+
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	secret, err := certificateSecret()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Certificates are not configured"})
+		return
+	}
+
+	var (
+		cert   works.ArtworkCertificate
+		issued bool
+	)
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var a works.Artwork
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
+			return err
+		}
+
+		editionLabel := ""
+		if a.IsMultiple() {
+			if req.EditionID == "" {
+				return fmt.Errorf("edition required")
+			}
+			var e works.ArtworkEdition
+			if err := tx.First(&e, "id = ? AND artwork_id = ?", req.EditionID, a.ID).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return fmt.Errorf("edition not found")
+				}
+				return err
+			}
+			editionLabel = e.Label(a.EditionSize, a.ArtistProofs)
+		} else if req.EditionID != "" {
+			return fmt.Errorf("edition not found")
+		}
+
+		// the active certificate wins unless a reissue is asked for
+		err := tx.First(&cert, "artwork_id = ? AND edition_id = ? AND revoked_at IS NULL", a.ID, req.EditionID).Error
+		if err == nil && !req.Reissue {
+			return nil
+		}
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		if err == nil {
+			if err := tx.Model(&works.ArtworkCertificate{}).
+				Where("id = ?", cert.ID).
+				Update("revoked_at", now).Error; err != nil {
+				return err
+			}
+		}
+
+		revID := a.PublishedRevisionID
+		if revID == nil {
+			revID = a.DraftRevisionID
+		}
+		if revID == nil {
+			return fmt.Errorf("artwork empty")
+		}
+		var rev works.ArtworkRevision
+		if err := tx.Preload("I18n").First(&rev, "id = ?", *revID).Error; err != nil {
+			return err
+		}
+
+		var artist users.User
+		if err := tx.Select("id", "name", "lastname").First(&artist, userID).Error; err != nil {
+			return err
+		}
+
+		cert = works.ArtworkCertificate{
+			Code:              newCertificateCode(),
+			UserID:            userID,
+			ArtworkID:         a.ID,
+			EditionID:         req.EditionID,
+			ArtworkRevisionID: rev.ID,
+			Artist:            strings.TrimSpace(artist.Name + " " + artist.Lastname),
+			Title:             pickArtworkTitle(rev.I18n, req.Lang),
+			Year:              rev.YearDisplay(),
+			Medium:            rev.MediumDisplay(req.Lang),
+			Size:              rev.SizeDisplay(req.Lang),
+			Edition:           editionLabel,
+			Lang:              req.Lang,
+			ImageID:           rev.ImageID,
+			IssuedAt:          now,
+		}
+		cert.Signature = cert.Sign(secret)
+		issued = true
+		return tx.Create(&cert).Error
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork not found"})
+			return
+		}
+		switch err.Error() {
+		case "edition required", "edition not found", "artwork empty":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue certificate", "details": err.Error()})
+		return
+	}
+
+	if err := database.DB.Preload("Image").First(&cert, "id = ?", cert.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load certificate", "details": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := renderCertificatePDF(c.Request.Context(), &buf, cert); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render certificate", "details": err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if issued {
+		status = http.StatusCreated
+	}
+	c.Header("X-Certificate-Code", cert.Code)
+	c.Header("Content-Disposition", `attachment; filename="certificate-`+cert.Code+`.pdf"`)
+	c.Data(status, "application/pdf", buf.Bytes())
+}
+
+// ------------------------------
+// GET /verify-certificate/:code (public)
+// ------------------------------
+func VerifyCertificate(c *gin.Context) {
+	code := strings.ToUpper(strings.TrimSpace(c.Param("code")))
+
+	secret, err := certificateSecret()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Certificates are not configured"})
+		return
+	}
+
+	var cert works.ArtworkCertificate
+	if err := database.DB.Preload("Image.Variants").First(&cert, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Certificate not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load certificate"})
+		return
+	}
+
+	out := CertificateVerificationDTO{
+		Code:      cert.Code,
+		Status:    "valid",
+		Artist:    cert.Artist,
+		Title:     cert.Title,
+		Year:      cert.Year,
+		Medium:    cert.Medium,
+		Size:      cert.Size,
+		Edition:   cert.Edition,
+		Image:     toImageRefDTO(cert.Image),
+		IssuedAt:  cert.IssuedAt,
+		RevokedAt: cert.RevokedAt,
+	}
+	switch {
+	case !cert.Verify(secret):
+		out.Status = "invalid"
+	case cert.RevokedAt != nil:
+		out.Status = "revoked"
+	}
+	out.Valid = out.Status == "valid"
+	c.JSON(http.StatusOK, out)
+}
+
+// ---------- rendering
+
+var certificateLabels = map[string]map[string]string{
+	"en": {"heading": "Certificate of Authenticity", "title": "Title", "year": "Year", "medium": "Medium", "size": "Dimensions", "edition": "Edition",
+		"statement": "I hereby certify that the work described above is an original work of art created by me.", "signature": "Signature",
+		"code": "Certificate no.", "issued": "Issued", "verify": "Verify at"},
+	"de": {"heading": "Echtheitszertifikat", "title": "Titel", "year": "Jahr", "medium": "Technik", "size": "Maße", "edition": "Auflage",
+		"statement": "Hiermit bestätige ich, dass das oben beschriebene Werk ein von mir geschaffenes Originalkunstwerk ist.", "signature": "Unterschrift",
+		"code": "Zertifikat-Nr.", "issued": "Ausgestellt", "verify": "Prüfen unter"},
+	"fr": {"heading": "Certificat d'authenticité", "title": "Titre", "year": "Année", "medium": "Technique", "size": "Dimensions", "edition": "Édition",
+		"statement": "Je certifie que l'œuvre décrite ci-dessus est une œuvre originale réalisée par moi-même.", "signature": "Signature",
+		"code": "Certificat n°", "issued": "Délivré le", "verify": "Vérifier sur"},
+	"es": {"heading": "Certificado de autenticidad", "title": "Título", "year": "Año", "medium": "Técnica", "size": "Medidas", "edition": "Edición",
+		"statement": "Certifico que la obra descrita arriba es una obra de arte original creada por mí.", "signature": "Firma",
+		"code": "Certificado n.º", "issued": "Emitido", "verify": "Verificar en"},
+	"it": {"heading": "Certificato di autenticità", "title": "Titolo", "year": "Anno", "medium": "Tecnica", "size": "Misure", "edition": "Edizione",
+		"statement": "Certifico che l'opera sopra descritta è un'opera d'arte originale da me realizzata.", "signature": "Firma",
+		"code": "Certificato n.", "issued": "Rilasciato", "verify": "Verifica su"},
+}
+
+func certificateLabel(lang, key string) string {
+	if l, ok := certificateLabels[works.BaseLang(lang)]; ok {
+		return l[key]
+	}
+	return certificateLabels["en"][key]
+}
+
+func renderCertificatePDF(ctx context.Context, w io.Writer, cert works.ArtworkCertificate) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.SetTitle(certificateLabel(cert.Lang, "heading")+" "+cert.Code, true)
+	pdf.SetAuthor(cert.Artist, true)
+	pdf.SetCreator("works", true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // cp1252
+	label := func(key string) string { return tr(certificateLabel(cert.Lang, key)) }
+	contentW := pdfPageW - 2*pdfMargin
+
+	pdf.AddPage()
+	pdf.SetDrawColor(60, 60, 60)
+	pdf.Rect(10, 10, pdfPageW-20, pdfPageH-20, "D")
+
+	pdf.SetY(pdfMargin + 4)
+	pdf.SetFont("Helvetica", "B", 22)
+	pdf.CellFormat(contentW, 10, label("heading"), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.CellFormat(contentW, 7, tr(cert.Artist), "", 1, "C", false, 0, "")
+
+	// image
+	y := pdf.GetY() + 8
+	images := &pdfImages{ctx: ctx, pdf: pdf, names: map[string]string{}}
+	if name := images.register(cert.Image); name != "" {
+		images.draw(name, pdfMargin+20, y, contentW-40, 100)
+		y += 108
+	}
+
+	// details
+	rows := [][2]string{
+		{"title", cert.Title},
+		{"year", cert.Year},
+		{"medium", cert.Medium},
+		{"size", cert.Size},
+		{"edition", cert.Edition},
+	}
+	pdf.SetXY(pdfMargin, y)
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		pdf.SetX(pdfMargin + 10)
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(40, 7, label(row[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.MultiCell(contentW-50, 7, tr(row[1]), "", "L", false)
+	}
+
+	pdf.Ln(6)
+	pdf.SetX(pdfMargin + 10)
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.MultiCell(contentW-20, 5, label("statement"), "", "L", false)
+
+	// signature line
+	sigY := pdfPageH - pdfMargin - 45
+	pdf.Line(pdfMargin+10, sigY, pdfMargin+90, sigY)
+	pdf.SetXY(pdfMargin+10, sigY+1)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(80, 5, label("signature")+" - "+tr(cert.Artist), "", 0, "L", false, 0, "")
+
+	// code, date and QR
+	verifyURL := CertificateVerifyURL(cert.Code)
+	pdf.SetXY(pdfMargin+10, sigY+12)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(100, 5, label("code")+" "+cert.Code, "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(100, 5, label("issued")+" "+cert.IssuedAt.Format("2006-01-02"), "", 2, "L", false, 0, "")
+	pdf.SetTextColor(90, 90, 90)
+	pdf.CellFormat(100, 5, label("verify")+" "+tr(verifyURL), "", 2, "L", false, 0, "")
+
+	png, err := qrcode.Encode(verifyURL, qrcode.Medium, 512)
+	if err != nil {
+		return err
+	}
+	pdf.RegisterImageOptionsReader("qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+	qrSize := 35.0
+	pdf.ImageOptions("qr", pdfPageW-pdfMargin-10-qrSize, sigY-5, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	return pdf.Output(w)
+}
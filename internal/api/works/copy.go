@@ -1,7 +1,9 @@
 package works
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"registration-app/database"
 	"registration-app/internal/domain/media"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // POST /templates/series/:id/copy
@@ -53,42 +56,12 @@ func CopyTemplateSeriesToUser(c *gin.Context) {
 		}
 		newSeriesID = newSeries.ID
 
-		// 3) Copy series revision image (duplicate image row)
-		var newSeriesImageID *string
-		if tpl.PublishedRevision.Image != nil {
-			img := media.Image{
-				OriginalPath: tpl.PublishedRevision.Image.OriginalPath,
-				WebpPath:     tpl.PublishedRevision.Image.WebpPath,
-				AvifPath:     tpl.PublishedRevision.Image.AvifPath,
-			}
-			if err := tx.Create(&img).Error; err != nil {
-				return err
-			}
-			newSeriesImageID = &img.ID
-		}
-
-		// 4) Create draft revision for the new series
-		newSeriesRev := dw.SeriesRevision{
-			SeriesID: newSeries.ID,
-			ImageID:  newSeriesImageID,
-		}
-		if err := tx.Create(&newSeriesRev).Error; err != nil {
+		// 3) Draft revision for the new series (fields, i18n, own image reference)
+		newSeriesRev, err := cloneSeriesRevision(tx, tpl.PublishedRevision, newSeries.ID)
+		if err != nil {
 			return err
 		}
 
-		for _, row := range tpl.PublishedRevision.I18n {
-			out := dw.SeriesI18nRevision{
-				SeriesRevisionID: newSeriesRev.ID,
-				Lang:             row.Lang,
-				Title:            row.Title,
-				DescriptionSerie: row.DescriptionSerie,
-				Year:             row.Year,
-			}
-			if err := tx.Create(&out).Error; err != nil {
-				return err
-			}
-		}
-
 		// point series.draft_revision_id to this new revision
 		if err := tx.Model(&dw.Series{}).
 			Where("id = ?", newSeries.ID).
@@ -96,88 +69,319 @@ func CopyTemplateSeriesToUser(c *gin.Context) {
 			return err
 		}
 
-		// 5) Copy artworks identities + draft revisions
+		// 4) Copy artworks identities + draft revisions
 		for _, art := range tpl.Items {
-			// create artwork identity
-			newArt := dw.Artwork{
-				OwnerType: dw.OwnerUser,
-				UserID:    &uid,
-				SeriesID:  newSeries.ID,
-				SortIndex: art.SortIndex,
-				IDLocked:  false,
-				Sold:      false,
-			}
-			if err := tx.Create(&newArt).Error; err != nil {
+			if _, err := copyArtwork(tx, art, newSeries.ID, uid, art.SortIndex, false); err != nil {
 				return err
 			}
+		}
 
-			// copy artwork revision image
-			var newArtImageID *string
-			if art.PublishedRevision != nil && art.PublishedRevision.Image != nil {
-				img := media.Image{
-					OriginalPath: art.PublishedRevision.Image.OriginalPath,
-					WebpPath:     art.PublishedRevision.Image.WebpPath,
-					AvifPath:     art.PublishedRevision.Image.AvifPath,
-				}
-				if err := tx.Create(&img).Error; err != nil {
-					return err
-				}
-				newArtImageID = &img.ID
-			}
+		return nil
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template series not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to copy template", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"series_id": newSeriesID,
+	})
+}
+
+// ------------------------------
+// POST /artworks/:id/move
+// body: { "series_id": "...", "position": 0 } (position omitted = append)
+// ------------------------------
+func MoveArtwork(c *gin.Context) {
+	id := c.Param("id")
+
+	var req MoveArtworkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-			// create draft revision
-			newArtRev := dw.ArtworkRevision{
-				ArtworkID: newArt.ID,
-				ImageID:   newArtImageID,
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var sortIndex int
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var a dw.Artwork
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, dw.OwnerUser, userID).Error; err != nil {
+			return err
+		}
+		if a.IDLocked {
+			return fmt.Errorf("locked")
+		}
+
+		// both series must belong to the user and be editable
+		var series []dw.Series
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND owner_type = ? AND user_id = ?", []string{a.SeriesID, req.SeriesID}, dw.OwnerUser, userID).
+			Find(&series).Error; err != nil {
+			return err
+		}
+		found := false
+		for _, s := range series {
+			if s.IDLocked {
+				return fmt.Errorf("locked")
 			}
-			if art.PublishedRevision != nil {
-				newArtRev.Year = art.PublishedRevision.Year
-				newArtRev.Medium = art.PublishedRevision.Medium
-				newArtRev.SizeCM = art.PublishedRevision.SizeCM
-				newArtRev.Price = art.PublishedRevision.Price
+			if s.ID == req.SeriesID {
+				found = true
 			}
+		}
+		if !found {
+			return gorm.ErrRecordNotFound
+		}
 
-			if err := tx.Create(&newArtRev).Error; err != nil {
+		// close the gap in the source series
+		if req.SeriesID != a.SeriesID {
+			ids, err := orderedArtworkIDs(tx, a.SeriesID, a.ID)
+			if err != nil {
 				return err
 			}
-
-			// i18n copy
-			if art.PublishedRevision != nil {
-				for _, t := range art.PublishedRevision.I18n {
-					out := dw.ArtworkI18nRevision{
-						ArtworkRevisionID: newArtRev.ID,
-						Lang:              t.Lang,
-						Title:             t.Title,
-						Description:       t.Description,
-						Notes:             t.Notes,
-					}
-					if err := tx.Create(&out).Error; err != nil {
-						return err
-					}
-				}
+			if err := renumberArtworks(tx, ids); err != nil {
+				return err
 			}
-
-			// point artwork.draft_revision_id
 			if err := tx.Model(&dw.Artwork{}).
-				Where("id = ?", newArt.ID).
-				Update("draft_revision_id", newArtRev.ID).Error; err != nil {
+				Where("id = ?", a.ID).
+				Update("series_id", req.SeriesID).Error; err != nil {
 				return err
 			}
 		}
 
-		return nil
+		ids, err := orderedArtworkIDs(tx, req.SeriesID, a.ID)
+		if err != nil {
+			return err
+		}
+		ids, sortIndex = insertAt(ids, a.ID, req.Position)
+		return renumberArtworks(tx, ids)
 	})
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Template series not found"})
+			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork or series not found"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to copy template", "details": err.Error()})
+		if err.Error() == "locked" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Artwork or series is locked"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to move artwork", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"series_id": newSeriesID,
+	c.JSON(http.StatusOK, gin.H{"status": "moved", "series_id": req.SeriesID, "sort_index": sortIndex})
+}
+
+// ------------------------------
+// POST /artworks/:id/duplicate
+// body (optional): { "series_id": "..." } (default: same series, right after the original)
+// ------------------------------
+func DuplicateArtwork(c *gin.Context) {
+	id := c.Param("id")
+
+	var req DuplicateArtworkRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var newArtworkID, targetSeriesID string
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var src dw.Artwork
+		if err := tx.
+			Preload("DraftRevision.Image").
+			Preload("DraftRevision.I18n").
+			Preload("PublishedRevision.Image").
+			Preload("PublishedRevision.I18n").
+			First(&src, "id = ? AND owner_type = ? AND user_id = ?", id, dw.OwnerUser, userID).Error; err != nil {
+			return err
+		}
+
+		targetSeriesID = src.SeriesID
+		if req.SeriesID != nil && *req.SeriesID != "" {
+			targetSeriesID = *req.SeriesID
+		}
+		var s dw.Series
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&s, "id = ? AND owner_type = ? AND user_id = ?", targetSeriesID, dw.OwnerUser, userID).Error; err != nil {
+			return err
+		}
+		if s.IDLocked {
+			return fmt.Errorf("locked")
+		}
+
+		dup, err := copyArtwork(tx, src, s.ID, userID, 0, true)
+		if err != nil {
+			return err
+		}
+		newArtworkID = dup.ID
+		if err := copyArtworkTerms(tx, src.ID, dup.ID); err != nil {
+			return err
+		}
+
+		ids, err := orderedArtworkIDs(tx, s.ID, dup.ID)
+		if err != nil {
+			return err
+		}
+		position := len(ids)
+		if s.ID == src.SeriesID {
+			for i, aid := range ids {
+				if aid == src.ID {
+					position = i + 1
+					break
+				}
+			}
+		}
+		ids, _ = insertAt(ids, dup.ID, &position)
+		return renumberArtworks(tx, ids)
 	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork or series not found"})
+			return
+		}
+		if err.Error() == "locked" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Series is locked"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to duplicate artwork", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"artwork_id": newArtworkID, "series_id": targetSeriesID})
+}
+
+// ---------- copy helpers
+
+// copyArtwork creates a USER artwork in seriesID from src (revisions preloaded
+// with Image + I18n). The draft view of src becomes the new draft; withPublished
+// also copies the published revision as a published revision of the copy.
+func copyArtwork(tx *gorm.DB, src dw.Artwork, seriesID string, userID uint, sortIndex int, withPublished bool) (*dw.Artwork, error) {
+	uid := userID
+	newArt := dw.Artwork{
+		OwnerType: dw.OwnerUser,
+		UserID:    &uid,
+		SeriesID:  seriesID,
+		SortIndex: sortIndex,
+		IDLocked:  false,
+		Sold:      false,
+	}
+	if err := tx.Create(&newArt).Error; err != nil {
+		return nil, err
+	}
+	// same edition structure, fresh inventory
+	if src.IsMultiple() {
+		if err := syncEditions(tx, newArt.ID, src.EditionSize, src.ArtistProofs); err != nil {
+			return nil, err
+		}
+	}
+
+	draftSrc := src.DraftRevision
+	updates := map[string]interface{}{}
+
+	if withPublished && src.PublishedRevision != nil {
+		pub, err := cloneArtworkRevision(tx, src.PublishedRevision, newArt.ID)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		if err := tx.Model(&dw.ArtworkRevision{}).
+			Where("id = ?", pub.ID).
+			Update("published_at", now).Error; err != nil {
+			return nil, err
+		}
+		updates["published_revision_id"] = pub.ID
+		newArt.PublishedRevisionID = &pub.ID
+	} else if draftSrc == nil {
+		draftSrc = src.PublishedRevision
+	}
+
+	if draftSrc != nil {
+		dr, err := cloneArtworkRevision(tx, draftSrc, newArt.ID)
+		if err != nil {
+			return nil, err
+		}
+		updates["draft_revision_id"] = dr.ID
+		newArt.DraftRevisionID = &dr.ID
+	}
+
+	if len(updates) > 0 {
+		if err := tx.Model(&dw.Artwork{}).Where("id = ?", newArt.ID).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &newArt, nil
+}
+
+// ownImageID returns the image a cloned or copied revision should reference: uploaded
+// images are immutable and shared, legacy path-only rows are duplicated because
+// upsertImage rewrites them in place. nil img = nothing to change.
+func ownImageID(tx *gorm.DB, img *media.Image) (*string, error) {
+	if img == nil {
+		return nil, nil
+	}
+	if img.StorageKey != nil {
+		return &img.ID, nil
+	}
+
+	dup := media.Image{
+		OriginalPath: img.OriginalPath,
+		WebpPath:     img.WebpPath,
+		AvifPath:     img.AvifPath,
+	}
+	if err := tx.Create(&dup).Error; err != nil {
+		return nil, err
+	}
+	return &dup.ID, nil
+}
+
+// orderedArtworkIDs lists the artworks of seriesID by sort_index, without excludeID.
+func orderedArtworkIDs(tx *gorm.DB, seriesID, excludeID string) ([]string, error) {
+	var ids []string
+	err := tx.Model(&dw.Artwork{}).
+		Where("series_id = ? AND id <> ?", seriesID, excludeID).
+		Order("sort_index ASC, created_at ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// insertAt puts id at position (nil or out of range = end) and returns the new index.
+func insertAt(ids []string, id string, position *int) ([]string, int) {
+	pos := len(ids)
+	if position != nil && *position >= 0 && *position < len(ids) {
+		pos = *position
+	}
+	out := make([]string, 0, len(ids)+1)
+	out = append(out, ids[:pos]...)
+	out = append(out, id)
+	out = append(out, ids[pos:]...)
+	return out, pos
+}
+
+// renumberArtworks writes sort_index 0..n-1 in the given order.
+func renumberArtworks(tx *gorm.DB, ids []string) error {
+	for i, id := range ids {
+		if err := tx.Model(&dw.Artwork{}).
+			Where("id = ?", id).
+			Update("sort_index", i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
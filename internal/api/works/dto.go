@@ -1,11 +1,16 @@
 package works
 
+import "time"
+
 type LangString string
 
 // ---------- requests
 
+// ImageInput references an uploaded image by ID (POST /media/images),
+// or carries legacy paths directly.
 type ImageInput struct {
-	OriginalPath string  `json:"original_path" binding:"required"`
+	ID           *string `json:"id"`
+	OriginalPath string  `json:"original_path"`
 	WebpPath     *string `json:"webp_path"`
 	AvifPath     *string `json:"avif_path"`
 }
@@ -23,15 +28,78 @@ type ArtworkI18nInput struct {
 }
 
 type CreateSeriesRequest struct {
-	IDLocked bool                       `json:"id_locked"`
-	Image    *ImageInput                `json:"image"`
-	I18n     map[string]SeriesI18nInput `json:"i18n" binding:"required"` // { "en": {...}, "de": {...} }
+	SortIndex *int                       `json:"sort_index"`
+	IDLocked  bool                       `json:"id_locked"`
+	Image     *ImageInput                `json:"image"`
+	TermIDs   []string                   `json:"term_ids"`
+	I18n      map[string]SeriesI18nInput `json:"i18n" binding:"required"` // { "en": {...}, "de": {...} }
 }
 
 type UpdateSeriesRequest struct {
 	IDLocked *bool                      `json:"id_locked"`
 	Image    *ImageInput                `json:"image"`
-	I18n     map[string]SeriesI18nInput `json:"i18n"` // upsert languages
+	TermIDs  *[]string                  `json:"term_ids"` // full list; [] detaches all
+	I18n     map[string]SeriesI18nInput `json:"i18n"`     // upsert languages
+}
+
+type YearRangeInput struct {
+	From *int `json:"from"`
+	To   *int `json:"to"` // omitted = single year
+}
+
+type DimensionsInput struct {
+	Height *float64 `json:"height"`
+	Width  *float64 `json:"width"`
+	Depth  *float64 `json:"depth"`
+	Unit   string   `json:"unit"` // cm (default) | mm | in
+}
+
+type PriceDetailsInput struct {
+	Amount     *int64 `json:"amount"`     // minor units, e.g. 120050 = 1,200.50
+	Currency   string `json:"currency"`   // ISO 4217, default EUR
+	Visibility string `json:"visibility"` // show (default) | on_request | hidden
+}
+
+// Record dates are partial ISO dates: "1998", "1998-05" or "1998-05-14".
+
+type ProvenanceInput struct {
+	Owner    string                         `json:"owner"`
+	Location string                         `json:"location"`
+	DateFrom string                         `json:"date_from"`
+	DateTo   string                         `json:"date_to"`
+	I18n     map[string]ProvenanceI18nInput `json:"i18n"`
+}
+
+type ProvenanceI18nInput struct {
+	Note string `json:"note"`
+}
+
+type ExhibitionInput struct {
+	Venue    string                         `json:"venue"`
+	City     string                         `json:"city"`
+	Country  string                         `json:"country"`
+	DateFrom string                         `json:"date_from"`
+	DateTo   string                         `json:"date_to"`
+	I18n     map[string]ExhibitionI18nInput `json:"i18n"`
+}
+
+type ExhibitionI18nInput struct {
+	Title string `json:"title"`
+	Note  string `json:"note"`
+}
+
+type LiteratureInput struct {
+	Author    string                         `json:"author"`
+	Publisher string                         `json:"publisher"`
+	Date      string                         `json:"date"`
+	Pages     string                         `json:"pages"`
+	URL       string                         `json:"url"`
+	I18n      map[string]LiteratureI18nInput `json:"i18n"`
+}
+
+type LiteratureI18nInput struct {
+	Title string `json:"title"`
+	Note  string `json:"note"`
 }
 
 type CreateArtworkRequest struct {
@@ -45,6 +113,22 @@ type CreateArtworkRequest struct {
 	SizeCM string `json:"size_cm"`
 	Price  string `json:"price"`
 
+	// structured; free text above is parsed when these are omitted
+	YearRange    *YearRangeInput    `json:"year_range"`
+	MediumKey    *string            `json:"medium_key"`
+	Dimensions   *DimensionsInput   `json:"dimensions"`
+	PriceDetails *PriceDetailsInput `json:"price_details"`
+
+	// multiples; one edition row per copy is created
+	EditionSize  int `json:"edition_size"`
+	ArtistProofs int `json:"artist_proofs"`
+
+	Provenance  []ProvenanceInput `json:"provenance"`
+	Exhibitions []ExhibitionInput `json:"exhibitions"`
+	Literature  []LiteratureInput `json:"literature"`
+
+	TermIDs []string `json:"term_ids"` // tags and categories (user artworks only)
+
 	I18n map[string]ArtworkI18nInput `json:"i18n" binding:"required"`
 }
 
@@ -59,6 +143,21 @@ type UpdateArtworkRequest struct {
 	SizeCM *string `json:"size_cm"`
 	Price  *string `json:"price"`
 
+	YearRange    *YearRangeInput    `json:"year_range"`
+	MediumKey    *string            `json:"medium_key"`
+	Dimensions   *DimensionsInput   `json:"dimensions"`
+	PriceDetails *PriceDetailsInput `json:"price_details"`
+
+	EditionSize  *int `json:"edition_size"`
+	ArtistProofs *int `json:"artist_proofs"`
+
+	// full lists, in display order; sent = replaces the draft's list ([] clears)
+	Provenance  *[]ProvenanceInput `json:"provenance"`
+	Exhibitions *[]ExhibitionInput `json:"exhibitions"`
+	Literature  *[]LiteratureInput `json:"literature"`
+
+	TermIDs *[]string `json:"term_ids"` // full list; [] detaches all
+
 	I18n map[string]ArtworkI18nInput `json:"i18n"` // upsert languages
 }
 
@@ -66,6 +165,49 @@ type ReorderArtworksRequest struct {
 	ArtworkIDs []string `json:"artwork_ids" binding:"required"` // ordered list
 }
 
+// SchedulePublishRequest is the optional body of the publish endpoints.
+// No body (or a publish_at in the past) publishes immediately.
+type SchedulePublishRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+type ReorderSeriesRequest struct {
+	SeriesIDs []string `json:"series_ids" binding:"required"` // ordered list
+}
+
+type MoveArtworkRequest struct {
+	SeriesID string `json:"series_id" binding:"required"`
+	Position *int   `json:"position"` // 0-based; omitted = append
+}
+
+type DuplicateArtworkRequest struct {
+	SeriesID *string `json:"series_id"` // omitted = same series
+}
+
+type UpdateEditionRequest struct {
+	Status   *string    `json:"status"` // available | reserved | sold | gifted
+	BuyerRef *string    `json:"buyer_ref"`
+	SoldAt   *time.Time `json:"sold_at"` // default: now when marked sold/gifted
+}
+
 type PublishRequest struct {
 	Publish bool `json:"publish" binding:"required"`
 }
+
+type TermI18nInput struct {
+	Label string `json:"label" binding:"required"`
+}
+
+type CreateTermRequest struct {
+	Kind      string                   `json:"kind" binding:"required"` // tag | category
+	Slug      string                   `json:"slug"`                    // default: from the English label
+	SortIndex *int                     `json:"sort_index"`
+	I18n      map[string]TermI18nInput `json:"i18n" binding:"required"`
+}
+
+type UpdateTermRequest struct {
+	Slug      *string                  `json:"slug"`
+	SortIndex *int                     `json:"sort_index"`
+	I18n      map[string]TermI18nInput `json:"i18n"` // upsert languages
+}
@@ -0,0 +1,221 @@
+package works
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/*
+	Editions
+	--------
+	- edition_size / artist_proofs on create/update artwork keep one
+	  ArtworkEdition row per copy (1..n and AP 1..n)
+	- shrinking only drops copies that are still available
+	- each copy's status changes via PUT /artworks/:id/editions/:editionId
+	- artworks.sold mirrors "sold out" for multiples (syncArtworkSold)
+*/
+
+// maxEditionCopies caps edition_size and artist_proofs each.
+const maxEditionCopies = 1000
+
+func orderEditions(db *gorm.DB) *gorm.DB {
+	return db.Order("kind = 'ap' ASC, number ASC")
+}
+
+// syncEditions resizes the editions of artworkID to editionSize + artistProofs.
+func syncEditions(tx *gorm.DB, artworkID string, editionSize, artistProofs int) error {
+	if editionSize < 0 || editionSize > maxEditionCopies || artistProofs < 0 || artistProofs > maxEditionCopies {
+		return fmt.Errorf("invalid edition size")
+	}
+
+	var existing []works.ArtworkEdition
+	if err := tx.Where("artwork_id = ?", artworkID).Find(&existing).Error; err != nil {
+		return err
+	}
+
+	have := map[string]map[int]bool{works.EditionNumbered: {}, works.EditionArtistProof: {}}
+	var drop []string
+	for _, e := range existing {
+		limit := editionSize
+		if e.Kind == works.EditionArtistProof {
+			limit = artistProofs
+		}
+		if e.Number > limit {
+			if e.Status != works.EditionAvailable {
+				return fmt.Errorf("edition in use")
+			}
+			drop = append(drop, e.ID)
+			continue
+		}
+		have[e.Kind][e.Number] = true
+	}
+
+	if len(drop) > 0 {
+		if err := tx.Where("id IN ?", drop).Delete(&works.ArtworkEdition{}).Error; err != nil {
+			return err
+		}
+	}
+
+	var add []works.ArtworkEdition
+	for kind, n := range map[string]int{works.EditionNumbered: editionSize, works.EditionArtistProof: artistProofs} {
+		for i := 1; i <= n; i++ {
+			if !have[kind][i] {
+				add = append(add, works.ArtworkEdition{ArtworkID: artworkID, Kind: kind, Number: i, Status: works.EditionAvailable})
+			}
+		}
+	}
+	if len(add) > 0 {
+		if err := tx.Create(&add).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Model(&works.Artwork{}).
+		Where("id = ?", artworkID).
+		Updates(map[string]interface{}{"edition_size": editionSize, "artist_proofs": artistProofs}).Error; err != nil {
+		return err
+	}
+	return syncArtworkSold(tx, artworkID)
+}
+
+// syncArtworkSold sets artworks.sold = sold out for multiples; unique works keep
+// the flag as the artist set it.
+func syncArtworkSold(tx *gorm.DB, artworkID string) error {
+	var a works.Artwork
+	if err := tx.Preload("Editions").First(&a, "id = ?", artworkID).Error; err != nil {
+		return err
+	}
+	if !a.IsMultiple() {
+		return nil
+	}
+	sold := a.Availability() == works.AvailabilitySold
+	if sold == a.Sold {
+		return nil
+	}
+	return tx.Model(&works.Artwork{}).Where("id = ?", a.ID).Update("sold", sold).Error
+}
+
+func isEditionInputError(err error) bool {
+	switch err.Error() {
+	case "invalid edition size", "invalid edition status":
+		return true
+	}
+	return false
+}
+
+// ------------------------------
+// PUT /artworks/:id/editions/:editionId
+// ------------------------------
+func UpdateArtworkEdition(c *gin.Context) {
+	id := c.Param("id")
+	editionID := c.Param("editionId")
+
+	var req UpdateEditionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var a works.Artwork
+	var edition works.ArtworkEdition
+	var version string
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
+			return err
+		}
+		if a.IDLocked {
+			return fmt.Errorf("locked")
+		}
+		if err := checkArtworkIfMatch(c, tx, &a); err != nil {
+			return err
+		}
+
+		if err := tx.First(&edition, "id = ? AND artwork_id = ?", editionID, a.ID).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if req.Status != nil {
+			if !works.ValidEditionStatus(*req.Status) {
+				return fmt.Errorf("invalid edition status")
+			}
+			updates["status"] = *req.Status
+
+			switch *req.Status {
+			case works.EditionAvailable:
+				// back in stock: forget the previous buyer unless given
+				updates["buyer_ref"] = ""
+				updates["sold_at"] = nil
+			case works.EditionSold, works.EditionGifted:
+				if edition.SoldAt == nil && req.SoldAt == nil {
+					updates["sold_at"] = time.Now()
+				}
+			}
+		}
+		if req.BuyerRef != nil {
+			updates["buyer_ref"] = *req.BuyerRef
+		}
+		if req.SoldAt != nil {
+			updates["sold_at"] = *req.SoldAt
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&works.ArtworkEdition{}).
+				Where("id = ?", edition.ID).
+				Updates(updates).Error; err != nil {
+				return err
+			}
+			if err := tx.First(&edition, "id = ?", edition.ID).Error; err != nil {
+				return err
+			}
+			if err := syncArtworkSold(tx, a.ID); err != nil {
+				return err
+			}
+			// inventory is not revisioned; bump the identity so the version changes
+			if err := tx.Model(&works.Artwork{}).Where("id = ?", a.ID).Update("updated_at", time.Now()).Error; err != nil {
+				return err
+			}
+		}
+
+		var err error
+		version, err = currentArtworkVersion(tx, a.ID)
+		return err
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Edition not found"})
+			return
+		}
+		if err == errVersionMismatch {
+			respondArtworkVersionMismatch(c, database.DB, userID, id)
+			return
+		}
+		if err.Error() == "locked" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Artwork is locked"})
+			return
+		}
+		if isEditionInputError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update edition", "details": err.Error()})
+		return
+	}
+
+	setETag(c, version)
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "edition": toEditionDTO(edition, a.EditionSize, a.ArtistProofs), "version": version})
+}
@@ -0,0 +1,173 @@
+package works
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Optimistic concurrency
+	----------------------
+	- GET /series/:id and GET /artworks/:id send an ETag (= meta.version)
+	- PUT / publish / unpublish accept If-Match and answer 412 + current state on mismatch
+	- the version changes with the identity row, both revision pointers and the
+	  draft revision's updated_at (touched on every draft edit, see touchRevision)
+*/
+
+var errVersionMismatch = fmt.Errorf("version mismatch")
+
+func computeVersion(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(h[:12])
+}
+
+func derefString(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+// seriesVersion expects s.DraftRevision to be loaded when a draft exists.
+func seriesVersion(s works.Series) string {
+	draftUpdated := ""
+	if s.DraftRevision != nil {
+		draftUpdated = s.DraftRevision.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return computeVersion(
+		s.ID,
+		derefString(s.DraftRevisionID),
+		derefString(s.PublishedRevisionID),
+		s.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		draftUpdated,
+	)
+}
+
+// artworkVersion expects a.DraftRevision to be loaded when a draft exists.
+func artworkVersion(a works.Artwork) string {
+	draftUpdated := ""
+	if a.DraftRevision != nil {
+		draftUpdated = a.DraftRevision.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return computeVersion(
+		a.ID,
+		derefString(a.DraftRevisionID),
+		derefString(a.PublishedRevisionID),
+		a.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		draftUpdated,
+	)
+}
+
+func setETag(c *gin.Context, version string) {
+	c.Header("ETag", `"`+version+`"`)
+}
+
+// ifMatchSatisfied checks the If-Match header against version; no header = no check.
+func ifMatchSatisfied(c *gin.Context, version string) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		tag = strings.TrimPrefix(tag, "W/")
+		if strings.Trim(tag, `"`) == version {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSeriesIfMatch loads the draft revision of s (for its updated_at) and
+// returns errVersionMismatch when If-Match does not match.
+func checkSeriesIfMatch(c *gin.Context, tx *gorm.DB, s *works.Series) error {
+	if c.GetHeader("If-Match") == "" {
+		return nil
+	}
+	if s.DraftRevisionID != nil && s.DraftRevision == nil {
+		var dr works.SeriesRevision
+		if err := tx.Select("id", "updated_at").First(&dr, "id = ?", *s.DraftRevisionID).Error; err != nil {
+			return err
+		}
+		s.DraftRevision = &dr
+	}
+	if !ifMatchSatisfied(c, seriesVersion(*s)) {
+		return errVersionMismatch
+	}
+	return nil
+}
+
+func checkArtworkIfMatch(c *gin.Context, tx *gorm.DB, a *works.Artwork) error {
+	if c.GetHeader("If-Match") == "" {
+		return nil
+	}
+	if a.DraftRevisionID != nil && a.DraftRevision == nil {
+		var dr works.ArtworkRevision
+		if err := tx.Select("id", "updated_at").First(&dr, "id = ?", *a.DraftRevisionID).Error; err != nil {
+			return err
+		}
+		a.DraftRevision = &dr
+	}
+	if !ifMatchSatisfied(c, artworkVersion(*a)) {
+		return errVersionMismatch
+	}
+	return nil
+}
+
+// touchSeriesRevision bumps updated_at so i18n-only edits change the version too.
+func touchSeriesRevision(tx *gorm.DB, revID string) error {
+	return tx.Model(&works.SeriesRevision{}).Where("id = ?", revID).Update("updated_at", time.Now()).Error
+}
+
+func touchArtworkRevision(tx *gorm.DB, revID string) error {
+	return tx.Model(&works.ArtworkRevision{}).Where("id = ?", revID).Update("updated_at", time.Now()).Error
+}
+
+// currentSeriesVersion re-reads s after a write so the response carries the new ETag.
+func currentSeriesVersion(tx *gorm.DB, id string) (string, error) {
+	var s works.Series
+	if err := tx.Preload("DraftRevision").First(&s, "id = ?", id).Error; err != nil {
+		return "", err
+	}
+	return seriesVersion(s), nil
+}
+
+func currentArtworkVersion(tx *gorm.DB, id string) (string, error) {
+	var a works.Artwork
+	if err := tx.Preload("DraftRevision").First(&a, "id = ?", id).Error; err != nil {
+		return "", err
+	}
+	return artworkVersion(a), nil
+}
+
+// respondSeriesVersionMismatch answers 412 with the current draft view.
+func respondSeriesVersionMismatch(c *gin.Context, db *gorm.DB, userID uint, id string) {
+	s, err := loadUserSeries(db, userID, id)
+	if err != nil {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Series was modified"})
+		return
+	}
+	dto := toSerieDTO_DraftView(s)
+	setETag(c, dto.Meta.Version)
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Series was modified", "current": dto})
+}
+
+func respondArtworkVersionMismatch(c *gin.Context, db *gorm.DB, userID uint, id string) {
+	a, err := loadUserArtwork(db, userID, id)
+	if err != nil {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Artwork was modified"})
+		return
+	}
+	dto := toArtworkDTOFromRevision(a, pickArtworkRevisionDraftView(a))
+	setETag(c, dto.Meta.Version)
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Artwork was modified", "current": dto})
+}
@@ -0,0 +1,455 @@
+package works
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/media"
+	"registration-app/internal/domain/works"
+	"registration-app/internal/infra/storage"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Export
+	------
+	GET /works/export?format=json|csv|zip
+
+	- json: ExportDTO, every series and artwork with its draft view and, when
+	  published, its published view (all languages, editions, records, terms)
+	- csv:  one row per artwork (draft view), one column per field and language;
+	  empty series get a row with only the series columns; image is the original
+	  URL, image_id matches the image file of a zip export
+	- zip:  works.json + works.csv + images/<image id><ext> (originals from
+	  storage) + manifest.json listing every file with size and sha256
+	- trashed series/artworks are not exported
+*/
+
+const ExportVersion = 1
+
+type ExportDTO struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exportedAt"`
+	Terms      []TermDTO         `json:"terms"`
+	Series     []ExportSeriesDTO `json:"series"`
+}
+
+type ExportSeriesDTO struct {
+	SortIndex int                `json:"sortIndex"`
+	Draft     SerieDTO           `json:"draft"` // items are in Artworks
+	Published *SerieDTO          `json:"published,omitempty"`
+	Artworks  []ExportArtworkDTO `json:"artworks"`
+}
+
+type ExportArtworkDTO struct {
+	SortIndex int             `json:"sortIndex"`
+	Draft     ArtworkItemDTO  `json:"draft"`
+	Published *ArtworkItemDTO `json:"published,omitempty"`
+}
+
+type ExportManifestDTO struct {
+	Version    int                     `json:"version"`
+	ExportedAt time.Time               `json:"exportedAt"`
+	Series     int                     `json:"series"`
+	Artworks   int                     `json:"artworks"`
+	Files      []ExportManifestFileDTO `json:"files"`
+	Missing    []ExportMissingImageDTO `json:"missing"` // referenced images not found in storage
+}
+
+type ExportManifestFileDTO struct {
+	Path     string `json:"path"`
+	ImageID  string `json:"imageId,omitempty"`
+	Name     string `json:"name,omitempty"` // original upload name
+	MimeType string `json:"mimeType,omitempty"`
+	Bytes    int64  `json:"bytes"`
+	SHA256   string `json:"sha256"`
+}
+
+type ExportMissingImageDTO struct {
+	ImageID  string `json:"imageId"`
+	Original string `json:"original"`
+	Reason   string `json:"reason"`
+}
+
+// ------------------------------
+// GET /works/export?format=json|csv|zip
+// ------------------------------
+func ExportWorks(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" && format != "zip" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json, csv or zip"})
+		return
+	}
+
+	series, terms, err := loadExportSeries(database.DB, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load works", "details": err.Error()})
+		return
+	}
+
+	now := time.Now().UTC()
+	export := toExportDTO(series, terms, now)
+	filename := "works-export-" + now.Format("20060102-150405")
+
+	switch format {
+	case "json":
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`.json"`)
+		c.JSON(http.StatusOK, export)
+
+	case "csv":
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`.csv"`)
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := writeExportCSV(c.Writer, export); err != nil {
+			log.Printf("❌ export csv user %d: %v", userID, err)
+		}
+
+	case "zip":
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`.zip"`)
+		c.Header("Content-Type", "application/zip")
+		c.Status(http.StatusOK)
+		// headers are sent; errors can only end the stream early
+		if err := writeExportZIP(c.Request.Context(), c.Writer, export, exportImages(series)); err != nil {
+			log.Printf("❌ export zip user %d: %v", userID, err)
+		}
+	}
+}
+
+// loadExportSeries loads every live series of userID with everything the
+// draft and published views need, plus the user's terms.
+func loadExportSeries(db *gorm.DB, userID uint) ([]works.Series, []works.Term, error) {
+	var series []works.Series
+	err := userSeriesQuery(db, userID).
+		Preload("Terms", orderTerms).
+		Preload("Terms.I18n").
+		Preload("DraftRevision.Image.Variants").
+		Preload("DraftRevision.I18n").
+		Preload("PublishedRevision.Image.Variants").
+		Preload("PublishedRevision.I18n").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_index ASC")
+		}).
+		Preload("Items.Terms", orderTerms).
+		Preload("Items.Terms.I18n").
+		Preload("Items.Editions", orderEditions).
+		Preload("Items.DraftRevision.Image.Variants").
+		Preload("Items.DraftRevision.I18n").
+		Scopes(withArtworkRecords("Items.DraftRevision")).
+		Preload("Items.PublishedRevision.Image.Variants").
+		Preload("Items.PublishedRevision.I18n").
+		Scopes(withArtworkRecords("Items.PublishedRevision")).
+		Order(seriesOrder).
+		Find(&series).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var terms []works.Term
+	if err := orderTerms(userTermsQuery(db, userID).Preload("I18n")).Find(&terms).Error; err != nil {
+		return nil, nil, err
+	}
+	return series, terms, nil
+}
+
+func toExportDTO(series []works.Series, terms []works.Term, now time.Time) ExportDTO {
+	out := ExportDTO{
+		Version:    ExportVersion,
+		ExportedAt: now,
+		Terms:      toTermDTOs(terms),
+		Series:     make([]ExportSeriesDTO, 0, len(series)),
+	}
+
+	for _, s := range series {
+		head := s
+		head.Items = nil
+
+		es := ExportSeriesDTO{
+			SortIndex: s.SortIndex,
+			Draft:     toSerieDTO_DraftView(head),
+			Artworks:  make([]ExportArtworkDTO, 0, len(s.Items)),
+		}
+		if s.PublishedRevision != nil {
+			pub := toSerieDTO_PublishedView(head)
+			pub.Meta = es.Draft.Meta
+			es.Published = &pub
+		}
+
+		for _, a := range s.Items {
+			ea := ExportArtworkDTO{
+				SortIndex: a.SortIndex,
+				Draft:     toArtworkDTOFromRevision(a, pickArtworkRevisionDraftView(a)),
+			}
+			if a.PublishedRevision != nil {
+				pub := toArtworkDTOFromRevision(a, a.PublishedRevision)
+				ea.Published = &pub
+			}
+			es.Artworks = append(es.Artworks, ea)
+		}
+		out.Series = append(out.Series, es)
+	}
+	return out
+}
+
+// ---------- csv
+
+var exportSeriesI18nKeys = []string{"title"}
+var exportArtworkI18nKeys = []string{"title", "description", "notes"}
+
+// exportLangs collects every language used by series and artworks, sorted.
+func exportLangs(export ExportDTO) []string {
+	seen := map[string]bool{}
+	for _, s := range export.Series {
+		for lang := range s.Draft.I18n {
+			seen[lang] = true
+		}
+		for _, a := range s.Artworks {
+			for lang := range a.Draft.I18n {
+				seen[lang] = true
+			}
+		}
+	}
+	langs := make([]string, 0, len(seen))
+	for lang := range seen {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+func writeExportCSV(w io.Writer, export ExportDTO) error {
+	langs := exportLangs(export)
+
+	header := []string{"series_id"}
+	for _, key := range exportSeriesI18nKeys {
+		for _, lang := range langs {
+			header = append(header, "series_"+key+"_"+lang)
+		}
+	}
+	header = append(header,
+		"artwork_id", "sort_index", "state", "published", "has_draft", "sold", "availability",
+		"edition", "year", "medium", "size_cm", "price", "tags", "categories", "image", "image_id")
+	for _, key := range exportArtworkI18nKeys {
+		for _, lang := range langs {
+			header = append(header, key+"_"+lang)
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, s := range export.Series {
+		seriesCols := []string{s.Draft.ID}
+		for _, key := range exportSeriesI18nKeys {
+			for _, lang := range langs {
+				seriesCols = append(seriesCols, s.Draft.I18n[lang][key])
+			}
+		}
+
+		// empty series still get a row, so an import recreates them
+		if len(s.Artworks) == 0 {
+			row := append([]string{}, seriesCols...)
+			for len(row) < len(header) {
+				row = append(row, "")
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, ea := range s.Artworks {
+			a := ea.Draft
+			edition := ""
+			if a.Edition != nil {
+				edition = strconv.Itoa(a.Edition.Size)
+				if a.Edition.ArtistProofs > 0 {
+					edition += " + " + strconv.Itoa(a.Edition.ArtistProofs) + " AP"
+				}
+			}
+			image, imageID := "", ""
+			if a.Image != nil {
+				image, imageID = a.Image.Original, a.Image.ID
+			}
+
+			row := append([]string{}, seriesCols...)
+			row = append(row,
+				a.ID,
+				strconv.Itoa(ea.SortIndex),
+				a.Meta.View,
+				strconv.FormatBool(a.Meta.Published),
+				strconv.FormatBool(a.Meta.HasDraft),
+				strconv.FormatBool(a.Sold),
+				a.Availability,
+				edition,
+				a.Year,
+				a.Medium,
+				a.SizeCM,
+				a.Price,
+				exportTermSlugs(a.Terms, works.TermTag),
+				exportTermSlugs(a.Terms, works.TermCategory),
+				image,
+				imageID,
+			)
+			for _, key := range exportArtworkI18nKeys {
+				for _, lang := range langs {
+					row = append(row, a.I18n[lang][key])
+				}
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func exportTermSlugs(terms []TermDTO, kind string) string {
+	var slugs []string
+	for _, t := range terms {
+		if t.Kind == kind {
+			slugs = append(slugs, t.Slug)
+		}
+	}
+	return strings.Join(slugs, ";")
+}
+
+// ---------- zip
+
+// exportImages lists the distinct images referenced by any exported revision.
+func exportImages(series []works.Series) []*media.Image {
+	seen := map[string]bool{}
+	var out []*media.Image
+	add := func(img *media.Image) {
+		if img != nil && !seen[img.ID] {
+			seen[img.ID] = true
+			out = append(out, img)
+		}
+	}
+	for _, s := range series {
+		if s.DraftRevision != nil {
+			add(s.DraftRevision.Image)
+		}
+		if s.PublishedRevision != nil {
+			add(s.PublishedRevision.Image)
+		}
+		for _, a := range s.Items {
+			if a.DraftRevision != nil {
+				add(a.DraftRevision.Image)
+			}
+			if a.PublishedRevision != nil {
+				add(a.PublishedRevision.Image)
+			}
+		}
+	}
+	return out
+}
+
+func writeExportZIP(ctx context.Context, w io.Writer, export ExportDTO, images []*media.Image) error {
+	zw := zip.NewWriter(w)
+	manifest := ExportManifestDTO{
+		Version:    ExportVersion,
+		ExportedAt: export.ExportedAt,
+		Series:     len(export.Series),
+		Files:      []ExportManifestFileDTO{},
+		Missing:    []ExportMissingImageDTO{},
+	}
+	for _, s := range export.Series {
+		manifest.Artworks += len(s.Artworks)
+	}
+
+	// works.json / works.csv
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZIPFile(zw, &manifest, "works.json", "", "application/json", bytes.NewReader(data)); err != nil {
+		return err
+	}
+	var csvBuf bytes.Buffer
+	if err := writeExportCSV(&csvBuf, export); err != nil {
+		return err
+	}
+	if err := writeZIPFile(zw, &manifest, "works.csv", "", "text/csv", &csvBuf); err != nil {
+		return err
+	}
+
+	// originals
+	for _, img := range images {
+		if img.StorageKey == nil || *img.StorageKey == "" {
+			manifest.Missing = append(manifest.Missing, ExportMissingImageDTO{ImageID: img.ID, Original: img.OriginalPath, Reason: "not in storage"})
+			continue
+		}
+		r, err := storage.Store.Open(ctx, *img.StorageKey)
+		if err != nil {
+			if err != storage.ErrNotFound {
+				return err
+			}
+			manifest.Missing = append(manifest.Missing, ExportMissingImageDTO{ImageID: img.ID, Original: img.OriginalPath, Reason: "not found"})
+			continue
+		}
+		name := "images/" + img.ID + path.Ext(*img.StorageKey)
+		err = writeZIPFile(zw, &manifest, name, img.ID, img.MimeType, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+		manifest.Files[len(manifest.Files)-1].Name = img.OriginalName
+	}
+
+	mf, err := zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(mf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeZIPFile copies r into the archive at name and records it in the manifest.
+func writeZIPFile(zw *zip.Writer, manifest *ExportManifestDTO, name, imageID, mimeType string, r io.Reader) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), r)
+	if err != nil {
+		return err
+	}
+	manifest.Files = append(manifest.Files, ExportManifestFileDTO{
+		Path:     name,
+		ImageID:  imageID,
+		MimeType: mimeType,
+		Bytes:    n,
+		SHA256:   hex.EncodeToString(h.Sum(nil)),
+	})
+	return nil
+}
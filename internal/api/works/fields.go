@@ -0,0 +1,184 @@
+package works
+
+import (
+	"fmt"
+	"net/http"
+
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+)
+
+// artworkFieldsInput collects the revision fields of create/update requests.
+// nil = not sent. Free text without its structured counterpart is parsed
+// best-effort; structured values without free text also fill the free text.
+type artworkFieldsInput struct {
+	Year   *string
+	Medium *string
+	SizeCM *string
+	Price  *string
+
+	YearRange    *YearRangeInput
+	MediumKey    *string
+	Dimensions   *DimensionsInput
+	PriceDetails *PriceDetailsInput
+}
+
+func (r CreateArtworkRequest) fields() artworkFieldsInput {
+	in := artworkFieldsInput{
+		YearRange:    r.YearRange,
+		MediumKey:    r.MediumKey,
+		Dimensions:   r.Dimensions,
+		PriceDetails: r.PriceDetails,
+	}
+	if r.Year != "" {
+		in.Year = &r.Year
+	}
+	if r.Medium != "" {
+		in.Medium = &r.Medium
+	}
+	if r.SizeCM != "" {
+		in.SizeCM = &r.SizeCM
+	}
+	if r.Price != "" {
+		in.Price = &r.Price
+	}
+	return in
+}
+
+func (r UpdateArtworkRequest) fields() artworkFieldsInput {
+	return artworkFieldsInput{
+		Year:         r.Year,
+		Medium:       r.Medium,
+		SizeCM:       r.SizeCM,
+		Price:        r.Price,
+		YearRange:    r.YearRange,
+		MediumKey:    r.MediumKey,
+		Dimensions:   r.Dimensions,
+		PriceDetails: r.PriceDetails,
+	}
+}
+
+// artworkFieldUpdates returns the artwork_revisions column updates for in.
+func artworkFieldUpdates(in artworkFieldsInput) (map[string]interface{}, error) {
+	u := map[string]interface{}{}
+
+	// year
+	if in.YearRange != nil {
+		if in.YearRange.From == nil || (in.YearRange.To != nil && *in.YearRange.To < *in.YearRange.From) {
+			return nil, fmt.Errorf("invalid year range")
+		}
+		u["year_from"] = in.YearRange.From
+		u["year_to"] = in.YearRange.To
+		if in.Year == nil {
+			u["year"] = works.ArtworkRevision{YearFrom: in.YearRange.From, YearTo: in.YearRange.To}.YearDisplay()
+		}
+	} else if in.Year != nil {
+		from, to, _ := works.ParseYear(*in.Year)
+		u["year_from"] = from
+		u["year_to"] = to
+	}
+	if in.Year != nil {
+		u["year"] = *in.Year
+	}
+
+	// medium
+	if in.MediumKey != nil {
+		if *in.MediumKey != "" {
+			if _, ok := works.FindMedium(*in.MediumKey); !ok {
+				return nil, fmt.Errorf("invalid medium key")
+			}
+		}
+		u["medium_key"] = *in.MediumKey
+		if in.Medium == nil && *in.MediumKey != "" {
+			u["medium"] = works.MediumLabel(*in.MediumKey, "en")
+		}
+	} else if in.Medium != nil {
+		u["medium_key"] = works.MatchMediumKey(*in.Medium)
+	}
+	if in.Medium != nil {
+		u["medium"] = *in.Medium
+	}
+
+	// dimensions
+	if in.Dimensions != nil {
+		d := *in.Dimensions
+		if d.Unit == "" {
+			d.Unit = works.UnitCM
+		}
+		if !works.ValidDimensionUnit(d.Unit) {
+			return nil, fmt.Errorf("invalid dimension unit")
+		}
+		for _, v := range []*float64{d.Height, d.Width, d.Depth} {
+			if v != nil && *v <= 0 {
+				return nil, fmt.Errorf("invalid dimensions")
+			}
+		}
+		u["height"], u["width"], u["depth"], u["dimension_unit"] = d.Height, d.Width, d.Depth, d.Unit
+		if in.SizeCM == nil {
+			rev := works.ArtworkRevision{Height: d.Height, Width: d.Width, Depth: d.Depth, DimensionUnit: d.Unit}
+			u["size_cm"] = rev.SizeText()
+		}
+	} else if in.SizeCM != nil {
+		d, ok := works.ParseDimensions(*in.SizeCM)
+		if !ok {
+			d = works.Dimensions{Unit: works.UnitCM}
+		}
+		u["height"], u["width"], u["depth"], u["dimension_unit"] = d.Height, d.Width, d.Depth, d.Unit
+	}
+	if in.SizeCM != nil {
+		u["size_cm"] = *in.SizeCM
+	}
+
+	// price
+	if in.PriceDetails != nil {
+		p := *in.PriceDetails
+		if p.Visibility == "" {
+			p.Visibility = works.PriceShow
+		}
+		if !works.ValidPriceVisibility(p.Visibility) {
+			return nil, fmt.Errorf("invalid price visibility")
+		}
+		if p.Currency == "" {
+			p.Currency = works.DefaultCurrency
+		}
+		if !works.ValidCurrency(p.Currency) {
+			return nil, fmt.Errorf("invalid currency")
+		}
+		if p.Amount != nil && *p.Amount < 0 {
+			return nil, fmt.Errorf("invalid price amount")
+		}
+		u["price_minor"], u["price_currency"], u["price_visibility"] = p.Amount, p.Currency, p.Visibility
+		if in.Price == nil {
+			rev := works.ArtworkRevision{PriceMinor: p.Amount, PriceCurrency: p.Currency, PriceVisibility: p.Visibility}
+			u["price"] = rev.PriceDisplay("en")
+		}
+	} else if in.Price != nil {
+		p, ok := works.ParsePrice(*in.Price)
+		if !ok {
+			p = works.PriceInfo{Visibility: works.PriceShow}
+		}
+		u["price_minor"], u["price_currency"], u["price_visibility"] = p.AmountMinor, p.Currency, p.Visibility
+	}
+	if in.Price != nil {
+		u["price"] = *in.Price
+	}
+
+	return u, nil
+}
+
+func isFieldInputError(err error) bool {
+	switch err.Error() {
+	case "invalid year range", "invalid medium key", "invalid dimension unit",
+		"invalid dimensions", "invalid price visibility", "invalid currency", "invalid price amount":
+		return true
+	}
+	return false
+}
+
+// ------------------------------
+// GET /works/vocabulary/mediums
+// ------------------------------
+func GetMediumVocabulary(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"mediums": works.Mediums})
+}
@@ -0,0 +1,268 @@
+package works
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	GET /works filters
+	------------------
+	?tag=a&tag=b  ?category=x   (slugs; a series' terms count for its artworks)
+	?medium=oil,acrylic         (medium_key of the viewed revision)
+	?year_from=2010&year_to=2015 (overlaps the artwork's year range)
+	?sold=true|false  ?state=published|unpublished|draft
+
+	Values within one filter are OR-ed, filters are AND-ed. Series without a
+	matching artwork are left out while any filter is set.
+*/
+
+type worksFilter struct {
+	Tags       []string
+	Categories []string
+	Mediums    []string
+	YearFrom   *int
+	YearTo     *int
+	Sold       *bool
+	States     []string
+
+	view string // "draft" | "published": which revision medium/year are read from
+}
+
+func queryList(c *gin.Context, key string) []string {
+	var out []string
+	for _, v := range c.QueryArray(key) {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+func parseWorksFilter(c *gin.Context, view string) (worksFilter, error) {
+	f := worksFilter{
+		Tags:       queryList(c, "tag"),
+		Categories: queryList(c, "category"),
+		Mediums:    queryList(c, "medium"),
+		States:     queryList(c, "state"),
+		view:       view,
+	}
+
+	for key, dst := range map[string]**int{"year_from": &f.YearFrom, "year_to": &f.YearTo} {
+		if v := c.Query(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return f, fmt.Errorf("invalid %s", key)
+			}
+			*dst = &n
+		}
+	}
+	if v := c.Query("sold"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, fmt.Errorf("invalid sold")
+		}
+		f.Sold = &b
+	}
+	for _, s := range f.States {
+		if s != "published" && s != "unpublished" && s != "draft" {
+			return f, fmt.Errorf("invalid state")
+		}
+	}
+	return f, nil
+}
+
+func (f worksFilter) active() bool {
+	return len(f.Tags) > 0 || len(f.Categories) > 0 || len(f.Mediums) > 0 ||
+		f.YearFrom != nil || f.YearTo != nil || f.Sold != nil || len(f.States) > 0
+}
+
+// revisionColumn is the artworks column holding the viewed revision.
+func (f worksFilter) revisionColumn() string {
+	if f.view == "published" {
+		return "artworks.published_revision_id"
+	}
+	return "COALESCE(artworks.draft_revision_id, artworks.published_revision_id)"
+}
+
+// artworkHasTermSQL is true when the artworks row has term t, directly or through its series.
+const artworkHasTermSQL = `(EXISTS (SELECT 1 FROM artwork_terms x WHERE x.artwork_id = artworks.id AND x.term_id = t.id)
+	OR EXISTS (SELECT 1 FROM series_terms y WHERE y.series_id = artworks.series_id AND y.term_id = t.id))`
+
+// artworkTermsInSQL lists (artwork_id, term_id) incl. terms inherited from the series,
+// for the artwork ids of a subquery; pass the subquery for both placeholders.
+const artworkTermsInSQL = `SELECT x.artwork_id, x.term_id FROM artwork_terms x WHERE x.artwork_id IN (?)
+	UNION SELECT a.id, y.term_id FROM artworks a JOIN series_terms y ON y.series_id = a.series_id WHERE a.id IN (?)`
+
+// apply adds the filters to an artworks query (userArtworksQuery).
+func (f worksFilter) apply(q *gorm.DB) *gorm.DB {
+	for kind, slugs := range map[string][]string{works.TermTag: f.Tags, works.TermCategory: f.Categories} {
+		if len(slugs) == 0 {
+			continue
+		}
+		q = q.Where(`EXISTS (SELECT 1 FROM terms t
+			WHERE t.user_id = artworks.user_id AND t.kind = ? AND t.slug IN ? AND `+artworkHasTermSQL+`)`, kind, slugs)
+	}
+
+	if len(f.Mediums) > 0 || f.YearFrom != nil || f.YearTo != nil {
+		rev := "SELECT 1 FROM artwork_revisions r WHERE r.id = " + f.revisionColumn()
+		if len(f.Mediums) > 0 {
+			q = q.Where("EXISTS ("+rev+" AND r.medium_key IN ?)", f.Mediums)
+		}
+		if f.YearFrom != nil {
+			q = q.Where("EXISTS ("+rev+" AND COALESCE(r.year_to, r.year_from) >= ?)", *f.YearFrom)
+		}
+		if f.YearTo != nil {
+			q = q.Where("EXISTS ("+rev+" AND r.year_from <= ?)", *f.YearTo)
+		}
+	}
+
+	if f.Sold != nil {
+		q = q.Where("artworks.sold = ?", *f.Sold)
+	}
+
+	if len(f.States) > 0 {
+		var conds []string
+		for _, s := range f.States {
+			conds = append(conds, stateCondition[s])
+		}
+		q = q.Where("(" + strings.Join(conds, " OR ") + ")")
+	}
+	return q
+}
+
+var stateCondition = map[string]string{
+	"published":   "artworks.published_revision_id IS NOT NULL",
+	"unpublished": "artworks.published_revision_id IS NULL",
+	"draft":       "(artworks.draft_revision_id IS NOT NULL AND artworks.draft_revision_id IS DISTINCT FROM artworks.published_revision_id)",
+}
+
+// ---------- facets
+
+type facetRow struct {
+	Value string
+	N     int
+}
+
+// worksFacets counts the artworks of base() per facet value; base must return
+// a fresh filtered artworks query on every call.
+func worksFacets(db *gorm.DB, userID uint, f worksFilter, base func() *gorm.DB) (*WorksFacetsDTO, error) {
+	out := &WorksFacetsDTO{
+		Tags:       []FacetCountDTO{},
+		Categories: []FacetCountDTO{},
+		Mediums:    []FacetCountDTO{},
+		Years:      []FacetCountDTO{},
+		Sold:       []FacetCountDTO{},
+		States:     []FacetCountDTO{},
+	}
+
+	var total int64
+	if err := base().Count(&total).Error; err != nil {
+		return nil, err
+	}
+	out.Total = int(total)
+	if total == 0 {
+		return out, nil
+	}
+
+	// terms
+	var termRows []facetRow
+	if err := db.Raw(`SELECT m.term_id AS value, COUNT(DISTINCT m.artwork_id) AS n
+		FROM (`+artworkTermsInSQL+`) m
+		GROUP BY m.term_id`, base().Select("artworks.id"), base().Select("artworks.id")).
+		Scan(&termRows).Error; err != nil {
+		return nil, err
+	}
+	if len(termRows) > 0 {
+		var terms []works.Term
+		if err := userTermsQuery(db, userID).Preload("I18n").
+			Order("sort_index ASC, slug ASC").
+			Find(&terms).Error; err != nil {
+			return nil, err
+		}
+		counts := map[string]int{}
+		for _, r := range termRows {
+			counts[r.Value] = r.N
+		}
+		for _, t := range terms {
+			n := counts[t.ID]
+			if n == 0 {
+				continue
+			}
+			item := FacetCountDTO{Value: t.Slug, Labels: toTermDTO(t).Labels, Count: n}
+			if t.Kind == works.TermCategory {
+				out.Categories = append(out.Categories, item)
+			} else {
+				out.Tags = append(out.Tags, item)
+			}
+		}
+	}
+
+	// mediums
+	var mediumRows []facetRow
+	if err := base().
+		Joins("JOIN artwork_revisions r ON r.id = " + f.revisionColumn()).
+		Where("r.medium_key <> ''").
+		Select("r.medium_key AS value, COUNT(*) AS n").
+		Group("r.medium_key").
+		Scan(&mediumRows).Error; err != nil {
+		return nil, err
+	}
+	sort.Slice(mediumRows, func(i, j int) bool { return mediumRows[i].N > mediumRows[j].N })
+	for _, r := range mediumRows {
+		item := FacetCountDTO{Value: r.Value, Count: r.N}
+		if m, ok := works.FindMedium(r.Value); ok {
+			item.Labels = m.Labels
+		}
+		out.Mediums = append(out.Mediums, item)
+	}
+
+	// years
+	var yearRows []facetRow
+	if err := base().
+		Joins("JOIN artwork_revisions r ON r.id = " + f.revisionColumn()).
+		Where("r.year_from IS NOT NULL").
+		Select("CAST(r.year_from AS text) AS value, COUNT(*) AS n").
+		Group("r.year_from").
+		Order("r.year_from DESC").
+		Scan(&yearRows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range yearRows {
+		out.Years = append(out.Years, FacetCountDTO{Value: r.Value, Count: r.N})
+	}
+
+	// sold
+	var soldRows []facetRow
+	if err := base().
+		Select("CASE WHEN artworks.sold THEN 'true' ELSE 'false' END AS value, COUNT(*) AS n").
+		Group("artworks.sold").
+		Scan(&soldRows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range soldRows {
+		out.Sold = append(out.Sold, FacetCountDTO{Value: r.Value, Count: r.N})
+	}
+
+	// states (draft overlaps with published)
+	for _, state := range []string{"published", "unpublished", "draft"} {
+		var n int64
+		if err := base().Where(stateCondition[state]).Count(&n).Error; err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			out.States = append(out.States, FacetCountDTO{Value: state, Count: int(n)})
+		}
+	}
+
+	return out, nil
+}
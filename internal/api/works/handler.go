@@ -3,12 +3,14 @@ package works
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"registration-app/database"
 	"registration-app/internal/domain/works"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func mustUserID(c *gin.Context) (uint, bool) {
@@ -31,33 +33,104 @@ func GetWorksJSON(c *gin.Context) {
 
 	view := c.DefaultQuery("view", "draft") // "draft" | "published"
 
-	var series []works.Series
-	err := userSeriesQuery(database.DB, userID).
-		Preload("DraftRevision.Image").
+	filter, err := parseWorksFilter(c, view)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := parseWorksPage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filteredArtworks := func(db *gorm.DB) *gorm.DB {
+		return filter.apply(userArtworksQuery(db, userID))
+	}
+
+	seriesQuery := userSeriesQuery(database.DB, userID)
+	if filter.active() {
+		seriesQuery = seriesQuery.Where("series.id IN (?)", filteredArtworks(database.DB).Select("artworks.series_id"))
+	}
+	seriesQuery = page.apply(seriesQuery).
+		Preload("DraftRevision.Image.Variants").
 		Preload("DraftRevision.I18n").
-		Preload("PublishedRevision.Image").
-		Preload("PublishedRevision.I18n").
-		Preload("Items", func(db *gorm.DB) *gorm.DB {
-			return userArtworksQuery(db, userID).Order("sort_index ASC")
-		}).
-		Preload("Items.DraftRevision.Image").
-		Preload("Items.DraftRevision.I18n").
-		Preload("Items.PublishedRevision.Image").
-		Preload("Items.PublishedRevision.I18n").
-		Order("created_at DESC").
-		Find(&series).Error
+		Preload("PublishedRevision.Image.Variants").
+		Preload("PublishedRevision.I18n")
+
+	if page.includes("terms") {
+		seriesQuery = seriesQuery.
+			Preload("Terms", orderTerms).
+			Preload("Terms.I18n")
+	}
+	if page.includes("items") {
+		seriesQuery = seriesQuery.
+			Preload("Items", func(db *gorm.DB) *gorm.DB {
+				return filteredArtworks(db).Order("sort_index ASC")
+			}).
+			Preload("Items.Editions", orderEditions).
+			Preload("Items.DraftRevision.Image.Variants").
+			Preload("Items.DraftRevision.I18n").
+			Preload("Items.PublishedRevision.Image.Variants").
+			Preload("Items.PublishedRevision.I18n")
+		if page.includes("terms") {
+			seriesQuery = seriesQuery.
+				Preload("Items.Terms", orderTerms).
+				Preload("Items.Terms.I18n")
+		}
+		if page.includes("records") {
+			seriesQuery = seriesQuery.
+				Scopes(withArtworkRecords("Items.DraftRevision")).
+				Scopes(withArtworkRecords("Items.PublishedRevision"))
+		}
+	}
+
+	var series []works.Series
+	err = seriesQuery.Order(seriesOrder).Find(&series).Error
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load works"})
 		return
 	}
 
-	out := WorksJSONDTO{Series: make([]SerieDTO, 0, len(series))}
+	series, pageInfo := page.page(series)
+	out := WorksJSONDTO{Series: make([]SerieDTO, 0, len(series)), Page: pageInfo}
 	for _, s := range series {
+		var dto SerieDTO
 		if view == "published" {
-			out.Series = append(out.Series, toSerieDTO_PublishedView(s))
+			dto = toSerieDTO_PublishedView(s)
 		} else {
-			out.Series = append(out.Series, toSerieDTO_DraftView(s))
+			dto = toSerieDTO_DraftView(s)
+		}
+		page.sparse(&dto)
+		out.Series = append(out.Series, dto)
+	}
+
+	if page.Summary {
+		ids := make([]string, 0, len(series))
+		for _, s := range series {
+			ids = append(ids, s.ID)
+		}
+		counts, err := seriesCounts(filteredArtworks(database.DB), ids)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count artworks", "details": err.Error()})
+			return
+		}
+		for i := range out.Series {
+			if n, ok := counts[out.Series[i].ID]; ok {
+				out.Series[i].Counts = n
+			} else {
+				out.Series[i].Counts = &SeriesCountsDTO{}
+			}
+		}
+	}
+
+	if page.includes("facets") {
+		out.Facets, err = worksFacets(database.DB, userID, filter, func() *gorm.DB {
+			return filteredArtworks(database.DB)
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count facets", "details": err.Error()})
+			return
 		}
 	}
 
@@ -69,16 +142,18 @@ func GetTemplateWorksJSON(c *gin.Context) {
 
 	err := templateSeriesQuery(database.DB).
 		Where("published_revision_id IS NOT NULL").
-		Preload("PublishedRevision.Image").
+		Preload("PublishedRevision.Image.Variants").
 		Preload("PublishedRevision.I18n").
 		Preload("Items", func(db *gorm.DB) *gorm.DB {
 			return templateArtworksQuery(db).
 				Where("published_revision_id IS NOT NULL").
 				Order("sort_index ASC")
 		}).
-		Preload("Items.PublishedRevision.Image").
+		Preload("Items.Editions", orderEditions).
+		Preload("Items.PublishedRevision.Image.Variants").
 		Preload("Items.PublishedRevision.I18n").
-		Order("created_at DESC").
+		Scopes(withArtworkRecords("Items.PublishedRevision")).
+		Order(seriesOrder).
 		Find(&series).Error
 
 	if err != nil {
@@ -107,56 +182,79 @@ func CreateSeries(c *gin.Context) {
 	if !ok {
 		return
 	}
-	uid := userID
 
+	var created works.Series
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		created, err = createUserSeries(tx, userID, req)
+		return err
+	})
 
-		s := works.Series{
-			OwnerType: works.OwnerUser,
-			UserID:    &uid,
-			IDLocked:  req.IDLocked,
-		}
-		if err := tx.Create(&s).Error; err != nil {
-			return err
+	if err != nil {
+		if isImageInputError(err) || err.Error() == "term not found" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create series", "details": err.Error()})
+		return
+	}
 
-		dr := works.SeriesRevision{SeriesID: s.ID}
-		if req.Image != nil {
-			imgID, err := upsertImage(tx, nil, req.Image.OriginalPath, req.Image.WebpPath, req.Image.AvifPath)
-			if err != nil {
-				return err
-			}
-			dr.ImageID = imgID
-		}
-		if err := tx.Create(&dr).Error; err != nil {
-			return err
-		}
+	c.JSON(http.StatusCreated, gin.H{"id": created.ID})
+}
 
-		for lang, v := range req.I18n {
-			row := works.SeriesI18nRevision{
-				SeriesRevisionID: dr.ID,
-				Lang:             lang,
-				Title:            v.Title,
-				DescriptionSerie: v.DescriptionSerie,
-				Year:             v.Year,
-			}
-			if err := tx.Create(&row).Error; err != nil {
-				return err
-			}
+// createUserSeries creates a series of userID with a draft revision from req
+// (POST /series and the catalogue import).
+func createUserSeries(tx *gorm.DB, userID uint, req CreateSeriesRequest) (works.Series, error) {
+	uid := userID
+
+	s := works.Series{
+		OwnerType: works.OwnerUser,
+		UserID:    &uid,
+		IDLocked:  req.IDLocked,
+	}
+	if req.SortIndex != nil {
+		s.SortIndex = *req.SortIndex
+	}
+	if err := tx.Create(&s).Error; err != nil {
+		return s, err
+	}
+	if len(req.TermIDs) > 0 {
+		if err := setSeriesTerms(tx, userID, s.ID, req.TermIDs); err != nil {
+			return s, err
 		}
+	}
 
-		// point draft at it
-		if err := tx.Model(&works.Series{}).Where("id = ?", s.ID).Update("draft_revision_id", dr.ID).Error; err != nil {
-			return err
+	dr := works.SeriesRevision{SeriesID: s.ID}
+	if req.Image != nil {
+		imgID, err := resolveImageInput(tx, &uid, nil, req.Image)
+		if err != nil {
+			return s, err
 		}
+		dr.ImageID = imgID
+	}
+	if err := tx.Create(&dr).Error; err != nil {
+		return s, err
+	}
 
-		c.JSON(http.StatusCreated, gin.H{"id": s.ID})
-		return nil
-	})
+	for lang, v := range req.I18n {
+		row := works.SeriesI18nRevision{
+			SeriesRevisionID: dr.ID,
+			Lang:             lang,
+			Title:            v.Title,
+			DescriptionSerie: v.DescriptionSerie,
+			Year:             v.Year,
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return s, err
+		}
+	}
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create series", "details": err.Error()})
+	// point draft at it
+	if err := tx.Model(&works.Series{}).Where("id = ?", s.ID).Update("draft_revision_id", dr.ID).Error; err != nil {
+		return s, err
 	}
+	s.DraftRevisionID = &dr.ID
+	return s, nil
 }
 
 // ------------------------------
@@ -176,14 +274,26 @@ func UpdateSeries(c *gin.Context) {
 		return
 	}
 
+	var version string
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 		var s works.Series
-		if err := tx.First(&s, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&s, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
 			return err
 		}
 		if s.IDLocked {
 			return fmt.Errorf("locked")
 		}
+		if err := checkSeriesIfMatch(c, tx, &s); err != nil {
+			return err
+		}
+
+		// terms stay on identity
+		if req.TermIDs != nil {
+			if err := setSeriesTerms(tx, userID, s.ID, *req.TermIDs); err != nil {
+				return err
+			}
+		}
 
 		// create or load draft revision
 		dr, err := ensureDraftSeriesRevision(tx, &s)
@@ -193,7 +303,7 @@ func UpdateSeries(c *gin.Context) {
 
 		// update image on draft revision
 		if req.Image != nil {
-			imgID, err := upsertImage(tx, dr.ImageID, req.Image.OriginalPath, req.Image.WebpPath, req.Image.AvifPath)
+			imgID, err := resolveImageInput(tx, &userID, dr.ImageID, req.Image)
 			if err != nil {
 				return err
 			}
@@ -236,8 +346,11 @@ func UpdateSeries(c *gin.Context) {
 			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
-		return nil
+		if err := touchSeriesRevision(tx, dr.ID); err != nil {
+			return err
+		}
+		version, err = currentSeriesVersion(tx, s.ID)
+		return err
 	})
 
 	if err != nil {
@@ -245,12 +358,24 @@ func UpdateSeries(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
 			return
 		}
+		if err == errVersionMismatch {
+			respondSeriesVersionMismatch(c, database.DB, userID, id)
+			return
+		}
 		if err.Error() == "locked" {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Series is locked"})
 			return
 		}
+		if isImageInputError(err) || err.Error() == "term not found" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update series", "details": err.Error()})
+		return
 	}
+
+	setETag(c, version)
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "version": version})
 }
 
 // ------------------------------
@@ -264,15 +389,22 @@ func DeleteSeries(c *gin.Context) {
 		return
 	}
 
-	res := database.DB.Delete(&works.Series{}, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID)
-	if res.Error != nil {
+	// moves the series and its artworks to the trash
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var s works.Series
+		if err := tx.First(&s, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
+			return err
+		}
+		return trashSeries(tx, s, time.Now())
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete series"})
 		return
 	}
-	if res.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
-		return
-	}
 
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
@@ -284,9 +416,23 @@ func PublishSeries(c *gin.Context) {
 		return
 	}
 
+	// optional body: { "publish_at": ..., "unpublish_at": ... }
+	var req SchedulePublishRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	now := time.Now()
+	if err := req.Validate(now); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	scheduled := req.IsScheduled(now)
+
+	var version string
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 		var s works.Series
-		if err := tx.First(&s,
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&s,
 			"id = ? AND owner_type = ? AND user_id = ?",
 			id, works.OwnerUser, userID,
 		).Error; err != nil {
@@ -295,20 +441,26 @@ func PublishSeries(c *gin.Context) {
 		if s.IDLocked {
 			return fmt.Errorf("locked")
 		}
+		if err := checkSeriesIfMatch(c, tx, &s); err != nil {
+			return err
+		}
 
-		// must have draft to publish; if none, create draft cloning published
-		dr, err := ensureDraftSeriesRevision(tx, &s)
-		if err != nil {
+		if !scheduled {
+			// must have draft to publish; if none, create draft cloning published
+			if _, err := publishSeriesDraft(tx, &s); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&works.Series{}).
+			Where("id = ?", s.ID).
+			Updates(req.scheduleUpdates(now)).Error; err != nil {
 			return err
 		}
 
-		// ✅ update SERIES table (not artworks)
-		return tx.Model(&works.Series{}).
-			Where("id = ? AND owner_type = ? AND user_id = ?", s.ID, works.OwnerUser, userID).
-			Updates(map[string]interface{}{
-				"published_revision_id": dr.ID,
-				"draft_revision_id":     nil,
-			}).Error
+		var err error
+		version, err = currentSeriesVersion(tx, s.ID)
+		return err
 	})
 
 	if err != nil {
@@ -316,6 +468,10 @@ func PublishSeries(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
 			return
 		}
+		if err == errVersionMismatch {
+			respondSeriesVersionMismatch(c, database.DB, userID, id)
+			return
+		}
 		if err.Error() == "locked" {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Series is locked"})
 			return
@@ -327,7 +483,10 @@ func PublishSeries(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "published"})
+	setETag(c, version)
+	resp := req.response(now)
+	resp["version"] = version
+	c.JSON(http.StatusOK, resp)
 }
 
 func UnpublishSeries(c *gin.Context) {
@@ -337,35 +496,26 @@ func UnpublishSeries(c *gin.Context) {
 		return
 	}
 
+	var version string
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 		var s works.Series
-		if err := tx.First(&s,
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&s,
 			"id = ? AND owner_type = ? AND user_id = ?",
 			id, works.OwnerUser, userID,
 		).Error; err != nil {
 			return err
 		}
 
-		// If we’re about to remove the published pointer, keep content as draft
-		if s.DraftRevisionID == nil && s.PublishedRevisionID != nil {
-			if err := tx.Model(&works.Series{}).
-				Where("id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).
-				Updates(map[string]interface{}{
-					"draft_revision_id":     s.PublishedRevisionID,
-					"published_revision_id": nil,
-				}).Error; err != nil {
-				return err
-			}
-		} else {
-			// draft already exists → just unpublish
-			if err := tx.Model(&works.Series{}).
-				Where("id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).
-				Update("published_revision_id", nil).Error; err != nil {
-				return err
-			}
+		if err := checkSeriesIfMatch(c, tx, &s); err != nil {
+			return err
+		}
+		if err := unpublishSeries(tx, &s); err != nil {
+			return err
 		}
 
-		return nil
+		var err error
+		version, err = currentSeriesVersion(tx, s.ID)
+		return err
 	})
 
 	if err != nil {
@@ -373,11 +523,16 @@ func UnpublishSeries(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
 			return
 		}
+		if err == errVersionMismatch {
+			respondSeriesVersionMismatch(c, database.DB, userID, id)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "unpublished"})
+	setETag(c, version)
+	c.JSON(http.StatusOK, gin.H{"status": "unpublished", "version": version})
 }
 
 // ------------------------------
@@ -396,85 +551,124 @@ func CreateArtwork(c *gin.Context) {
 	if !ok {
 		return
 	}
-	uid := userID
 
+	var created works.Artwork
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
-		// ensure USER series exists
-		var s works.Series
-		if err := tx.First(&s, "id = ? AND owner_type = ? AND user_id = ?", seriesID, works.OwnerUser, userID).Error; err != nil {
-			return err
-		}
+		var err error
+		created, err = createUserArtwork(tx, userID, seriesID, req)
+		return err
+	})
 
-		sortIndex := 0
-		if req.SortIndex != nil {
-			sortIndex = *req.SortIndex
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
 		}
-
-		// 1) create artwork identity
-		a := works.Artwork{
-			OwnerType: works.OwnerUser,
-			UserID:    &uid,
-			SeriesID:  s.ID,
-			SortIndex: sortIndex,
-			IDLocked:  req.IDLocked,
-			Sold:      req.Sold, // stays on identity
+		if err.Error() == "edition in use" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot remove editions that are reserved, sold or gifted"})
+			return
 		}
-		if err := tx.Create(&a).Error; err != nil {
-			return err
+		if isImageInputError(err) || isFieldInputError(err) || isEditionInputError(err) || isRecordInputError(err) || err.Error() == "term not found" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create artwork", "details": err.Error()})
+		return
+	}
 
-		// 2) create draft revision with fields
-		dr := works.ArtworkRevision{
-			ArtworkID: a.ID,
-			Year:      req.Year,
-			Medium:    req.Medium,
-			SizeCM:    req.SizeCM,
-			Price:     req.Price,
-		}
+	c.JSON(http.StatusCreated, gin.H{"id": created.ID})
+}
 
-		if req.Image != nil {
-			imgID, err := upsertImage(tx, nil, req.Image.OriginalPath, req.Image.WebpPath, req.Image.AvifPath)
-			if err != nil {
-				return err
-			}
-			dr.ImageID = imgID
-		}
+// createUserArtwork creates an artwork with a draft revision from req in
+// series seriesID of userID (POST /series/:id/artworks and the catalogue
+// import); gorm.ErrRecordNotFound when the series is not the user's.
+func createUserArtwork(tx *gorm.DB, userID uint, seriesID string, req CreateArtworkRequest) (works.Artwork, error) {
+	uid := userID
 
-		if err := tx.Create(&dr).Error; err != nil {
-			return err
-		}
+	// ensure USER series exists
+	var s works.Series
+	if err := tx.First(&s, "id = ? AND owner_type = ? AND user_id = ?", seriesID, works.OwnerUser, userID).Error; err != nil {
+		return works.Artwork{}, err
+	}
 
-		for lang, v := range req.I18n {
-			row := works.ArtworkI18nRevision{
-				ArtworkRevisionID: dr.ID,
-				Lang:              lang,
-				Title:             v.Title,
-				Description:       v.Description,
-				Notes:             v.Notes,
-			}
-			if err := tx.Create(&row).Error; err != nil {
-				return err
-			}
-		}
+	sortIndex := 0
+	if req.SortIndex != nil {
+		sortIndex = *req.SortIndex
+	}
 
-		// 3) point identity to draft revision
-		if err := tx.Model(&works.Artwork{}).
-			Where("id = ?", a.ID).
-			Update("draft_revision_id", dr.ID).Error; err != nil {
-			return err
+	// 1) create artwork identity
+	a := works.Artwork{
+		OwnerType: works.OwnerUser,
+		UserID:    &uid,
+		SeriesID:  s.ID,
+		SortIndex: sortIndex,
+		IDLocked:  req.IDLocked,
+		Sold:      req.Sold, // stays on identity
+	}
+	if err := tx.Create(&a).Error; err != nil {
+		return a, err
+	}
+	if req.EditionSize != 0 || req.ArtistProofs != 0 {
+		if err := syncEditions(tx, a.ID, req.EditionSize, req.ArtistProofs); err != nil {
+			return a, err
+		}
+	}
+	if len(req.TermIDs) > 0 {
+		if err := setArtworkTerms(tx, userID, a.ID, req.TermIDs); err != nil {
+			return a, err
 		}
+	}
 
-		c.JSON(http.StatusCreated, gin.H{"id": a.ID})
-		return nil
-	})
+	// 2) create draft revision with fields
+	dr := works.ArtworkRevision{ArtworkID: a.ID}
+
+	if req.Image != nil {
+		imgID, err := resolveImageInput(tx, &uid, nil, req.Image)
+		if err != nil {
+			return a, err
+		}
+		dr.ImageID = imgID
+	}
 
+	if err := tx.Create(&dr).Error; err != nil {
+		return a, err
+	}
+	fieldUpdates, err := artworkFieldUpdates(req.fields())
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
-			return
+		return a, err
+	}
+	if len(fieldUpdates) > 0 {
+		if err := tx.Model(&works.ArtworkRevision{}).
+			Where("id = ?", dr.ID).
+			Updates(fieldUpdates).Error; err != nil {
+			return a, err
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create artwork", "details": err.Error()})
 	}
+
+	for lang, v := range req.I18n {
+		row := works.ArtworkI18nRevision{
+			ArtworkRevisionID: dr.ID,
+			Lang:              lang,
+			Title:             v.Title,
+			Description:       v.Description,
+			Notes:             v.Notes,
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return a, err
+		}
+	}
+	if err := replaceArtworkRecords(tx, dr.ID, req.records()); err != nil {
+		return a, err
+	}
+
+	// 3) point identity to draft revision
+	if err := tx.Model(&works.Artwork{}).
+		Where("id = ?", a.ID).
+		Update("draft_revision_id", dr.ID).Error; err != nil {
+		return a, err
+	}
+	a.DraftRevisionID = &dr.ID
+	return a, nil
 }
 
 // ------------------------------
@@ -494,14 +688,19 @@ func UpdateArtwork(c *gin.Context) {
 		return
 	}
 
+	var version string
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 		var a works.Artwork
-		if err := tx.First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
 			return err
 		}
 		if a.IDLocked {
 			return fmt.Errorf("locked")
 		}
+		if err := checkArtworkIfMatch(c, tx, &a); err != nil {
+			return err
+		}
 
 		// identity updates (apply to both draft+published views in this phase)
 		identityUpdates := map[string]interface{}{}
@@ -522,6 +721,30 @@ func UpdateArtwork(c *gin.Context) {
 			}
 		}
 
+		if req.TermIDs != nil {
+			if err := setArtworkTerms(tx, userID, a.ID, *req.TermIDs); err != nil {
+				return err
+			}
+		}
+
+		// editions (inventory stays on identity); sold is derived for multiples
+		if req.EditionSize != nil || req.ArtistProofs != nil {
+			editionSize, artistProofs := a.EditionSize, a.ArtistProofs
+			if req.EditionSize != nil {
+				editionSize = *req.EditionSize
+			}
+			if req.ArtistProofs != nil {
+				artistProofs = *req.ArtistProofs
+			}
+			if err := syncEditions(tx, a.ID, editionSize, artistProofs); err != nil {
+				return err
+			}
+		} else if req.Sold != nil {
+			if err := syncArtworkSold(tx, a.ID); err != nil {
+				return err
+			}
+		}
+
 		// draft revision: create/load (clone published if needed)
 		dr, err := ensureDraftArtworkRevision(tx, &a)
 		fmt.Println("artwork", a.ID, "pub", a.PublishedRevisionID, "draft", a.DraftRevisionID, "dr", dr.ID)
@@ -530,19 +753,10 @@ func UpdateArtwork(c *gin.Context) {
 			return err
 		}
 
-		// revision scalar field updates
-		revUpdates := map[string]interface{}{}
-		if req.Year != nil {
-			revUpdates["year"] = *req.Year
-		}
-		if req.Medium != nil {
-			revUpdates["medium"] = *req.Medium
-		}
-		if req.SizeCM != nil {
-			revUpdates["size_cm"] = *req.SizeCM
-		}
-		if req.Price != nil {
-			revUpdates["price"] = *req.Price
+		// revision scalar field updates (free text + structured)
+		revUpdates, err := artworkFieldUpdates(req.fields())
+		if err != nil {
+			return err
 		}
 		if len(revUpdates) > 0 {
 			if err := tx.Model(&works.ArtworkRevision{}).
@@ -554,7 +768,7 @@ func UpdateArtwork(c *gin.Context) {
 
 		// image upsert (on revision)
 		if req.Image != nil {
-			imgID, err := upsertImage(tx, dr.ImageID, req.Image.OriginalPath, req.Image.WebpPath, req.Image.AvifPath)
+			imgID, err := resolveImageInput(tx, &userID, dr.ImageID, req.Image)
 			if err != nil {
 				return err
 			}
@@ -599,8 +813,16 @@ func UpdateArtwork(c *gin.Context) {
 			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
-		return nil
+		// provenance / exhibitions / literature (on revision)
+		if err := replaceArtworkRecords(tx, dr.ID, req.records()); err != nil {
+			return err
+		}
+
+		if err := touchArtworkRevision(tx, dr.ID); err != nil {
+			return err
+		}
+		version, err = currentArtworkVersion(tx, a.ID)
+		return err
 	})
 
 	if err != nil {
@@ -608,12 +830,28 @@ func UpdateArtwork(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork not found"})
 			return
 		}
+		if err == errVersionMismatch {
+			respondArtworkVersionMismatch(c, database.DB, userID, id)
+			return
+		}
 		if err.Error() == "locked" {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Artwork is locked"})
 			return
 		}
+		if err.Error() == "edition in use" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot remove editions that are reserved, sold or gifted"})
+			return
+		}
+		if isImageInputError(err) || isFieldInputError(err) || isEditionInputError(err) || isRecordInputError(err) || err.Error() == "term not found" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update artwork", "details": err.Error()})
+		return
 	}
+
+	setETag(c, version)
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "version": version})
 }
 
 // ------------------------------
@@ -627,6 +865,7 @@ func DeleteArtwork(c *gin.Context) {
 		return
 	}
 
+	// soft delete: the artwork goes to the trash
 	res := database.DB.Delete(&works.Artwork{}, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID)
 	if res.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete artwork"})
@@ -647,28 +886,48 @@ func PublishArtwork(c *gin.Context) {
 		return
 	}
 
+	// optional body: { "publish_at": ..., "unpublish_at": ... }
+	var req SchedulePublishRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	now := time.Now()
+	if err := req.Validate(now); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	scheduled := req.IsScheduled(now)
+
+	var version string
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 		var a works.Artwork
-		if err := tx.First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error; err != nil {
 			return err
 		}
 		if a.IDLocked {
 			return fmt.Errorf("locked")
 		}
+		if err := checkArtworkIfMatch(c, tx, &a); err != nil {
+			return err
+		}
 
-		// must have draft to publish; if none, create draft cloning published
-		dr, err := ensureDraftArtworkRevision(tx, &a)
-		if err != nil {
+		if !scheduled {
+			// must have draft to publish; if none, create draft cloning published
+			if _, err := publishArtworkDraft(tx, &a); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&works.Artwork{}).
+			Where("id = ?", a.ID).
+			Updates(req.scheduleUpdates(now)).Error; err != nil {
 			return err
 		}
 
-		// publish = point published_revision_id to draft revision
-		return tx.Model(&works.Artwork{}).
-			Where("id = ? AND owner_type = ? AND user_id = ?", a.ID, works.OwnerUser, userID).
-			Updates(map[string]interface{}{
-				"published_revision_id": dr.ID,
-				"draft_revision_id":     nil, // ✅ important
-			}).Error
+		var err error
+		version, err = currentArtworkVersion(tx, a.ID)
+		return err
 	})
 
 	if err != nil {
@@ -676,6 +935,10 @@ func PublishArtwork(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork not found"})
 			return
 		}
+		if err == errVersionMismatch {
+			respondArtworkVersionMismatch(c, database.DB, userID, id)
+			return
+		}
 		if err.Error() == "locked" {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Artwork is locked"})
 			return
@@ -684,7 +947,10 @@ func PublishArtwork(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "published"})
+	setETag(c, version)
+	resp := req.response(now)
+	resp["version"] = version
+	c.JSON(http.StatusOK, resp)
 }
 
 func UnpublishArtwork(c *gin.Context) {
@@ -694,27 +960,26 @@ func UnpublishArtwork(c *gin.Context) {
 		return
 	}
 
+	var version string
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 		var a works.Artwork
-		if err := tx.First(&a,
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&a,
 			"id = ? AND owner_type = ? AND user_id = ?",
 			id, works.OwnerUser, userID,
 		).Error; err != nil {
 			return err
 		}
 
-		if a.DraftRevisionID == nil && a.PublishedRevisionID != nil {
-			return tx.Model(&works.Artwork{}).
-				Where("id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).
-				Updates(map[string]interface{}{
-					"draft_revision_id":     a.PublishedRevisionID,
-					"published_revision_id": nil,
-				}).Error
+		if err := checkArtworkIfMatch(c, tx, &a); err != nil {
+			return err
+		}
+		if err := unpublishArtwork(tx, &a); err != nil {
+			return err
 		}
 
-		return tx.Model(&works.Artwork{}).
-			Where("id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).
-			Update("published_revision_id", nil).Error
+		var err error
+		version, err = currentArtworkVersion(tx, a.ID)
+		return err
 	})
 
 	if err != nil {
@@ -722,11 +987,16 @@ func UnpublishArtwork(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork not found"})
 			return
 		}
+		if err == errVersionMismatch {
+			respondArtworkVersionMismatch(c, database.DB, userID, id)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "unpublished"})
+	setETag(c, version)
+	c.JSON(http.StatusOK, gin.H{"status": "unpublished", "version": version})
 }
 
 // ------------------------------
@@ -781,6 +1051,107 @@ func ReorderArtworks(c *gin.Context) {
 	}
 }
 
+// ------------------------------
+// PUT /series/reorder (USER series only)
+// ------------------------------
+func ReorderSeries(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ReorderSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.SeriesIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "series_ids required"})
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		return reorderSeries(tx, func(db *gorm.DB) *gorm.DB { return userSeriesQuery(db, userID) }, req.SeriesIDs)
+	})
+	if err != nil {
+		respondReorderSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// ------------------------------
+// PUT /admin/templates/series/reorder
+// ------------------------------
+func ReorderTemplateSeries(c *gin.Context) {
+	var req ReorderSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.SeriesIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "series_ids required"})
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		return reorderSeries(tx, templateSeriesQuery, req.SeriesIDs)
+	})
+	if err != nil {
+		respondReorderSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// reorderSeries writes sort_index in the given order. ids must list every series
+// in scope exactly once ("invalid series_ids"); locked series keep their position ("locked").
+func reorderSeries(tx *gorm.DB, scope func(db *gorm.DB) *gorm.DB, ids []string) error {
+	var current []works.Series
+	if err := scope(tx).
+		Select("id", "id_locked").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order(seriesOrder).
+		Find(&current).Error; err != nil {
+		return err
+	}
+
+	pos := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if _, dup := pos[id]; dup {
+			return fmt.Errorf("invalid series_ids")
+		}
+		pos[id] = i
+	}
+	if len(pos) != len(current) {
+		return fmt.Errorf("invalid series_ids")
+	}
+	for i, s := range current {
+		p, ok := pos[s.ID]
+		if !ok {
+			return fmt.Errorf("invalid series_ids")
+		}
+		if s.IDLocked && p != i {
+			return fmt.Errorf("locked")
+		}
+	}
+
+	for i, seriesID := range ids {
+		if err := scope(tx).
+			Where("id = ?", seriesID).
+			Update("sort_index", i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// respondReorderSeriesError maps reorderSeries errors to responses.
+func respondReorderSeriesError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "invalid series_ids":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "series_ids must list every series exactly once"})
+	case "locked":
+		c.JSON(http.StatusConflict, gin.H{"error": "Locked series cannot be moved"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder series", "details": err.Error()})
+	}
+}
+
 // ------------------------------
 // GET /series/:id (USER, draft view: draft -> fallback to published)
 // ------------------------------
@@ -792,35 +1163,49 @@ func GetSeriesByID(c *gin.Context) {
 		return
 	}
 
+	s, err := loadUserSeries(database.DB, userID, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load series"})
+		return
+	}
+
+	dto := toSerieDTO_DraftView(s)
+	setETag(c, dto.Meta.Version)
+	c.JSON(http.StatusOK, dto)
+}
+
+// loadUserSeries loads a USER series with both revisions and its items (editor view).
+func loadUserSeries(db *gorm.DB, userID uint, id string) (works.Series, error) {
 	var s works.Series
-	err := database.DB.
+	err := db.
+		Preload("Terms", orderTerms).
+		Preload("Terms.I18n").
 		// series revisions
-		Preload("DraftRevision.Image").
+		Preload("DraftRevision.Image.Variants").
 		Preload("DraftRevision.I18n").
-		Preload("PublishedRevision.Image").
+		Preload("PublishedRevision.Image.Variants").
 		Preload("PublishedRevision.I18n").
 		// items identities
 		Preload("Items", func(db *gorm.DB) *gorm.DB {
 			return db.Where("owner_type = ? AND user_id = ?", works.OwnerUser, userID).
 				Order("sort_index ASC")
 		}).
+		Preload("Items.Terms", orderTerms).
+		Preload("Items.Terms.I18n").
 		// item revisions
-		Preload("Items.DraftRevision.Image").
+		Preload("Items.Editions", orderEditions).
+		Preload("Items.DraftRevision.Image.Variants").
 		Preload("Items.DraftRevision.I18n").
-		Preload("Items.PublishedRevision.Image").
+		Scopes(withArtworkRecords("Items.DraftRevision")).
+		Preload("Items.PublishedRevision.Image.Variants").
 		Preload("Items.PublishedRevision.I18n").
+		Scopes(withArtworkRecords("Items.PublishedRevision")).
 		First(&s, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error
-
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load series"})
-		return
-	}
-
-	c.JSON(http.StatusOK, toSerieDTO_DraftView(s))
+	return s, err
 }
 
 // ------------------------------
@@ -836,14 +1221,7 @@ func GetArtworkByID(c *gin.Context) {
 
 	view := c.DefaultQuery("view", "draft") // "draft" | "published"
 
-	var a works.Artwork
-	err := database.DB.
-		Preload("DraftRevision.Image").
-		Preload("DraftRevision.I18n").
-		Preload("PublishedRevision.Image").
-		Preload("PublishedRevision.I18n").
-		First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error
-
+	a, err := loadUserArtwork(database.DB, userID, id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork not found"})
@@ -852,25 +1230,32 @@ func GetArtworkByID(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load artwork"})
 		return
 	}
+	setETag(c, artworkVersion(a))
 
-	// choose revision based on view
-	var rev *works.ArtworkRevision
 	if view == "published" {
-		rev = a.PublishedRevision
-		if rev == nil {
-			// published view requested but not published
-			c.JSON(http.StatusOK, toArtworkDTOFromRevision(a, nil))
-			return
-		}
-	} else {
-		// draft view: draft if exists, else published
-		rev = a.DraftRevision
-		if rev == nil {
-			rev = a.PublishedRevision
-		}
+		// published revision only (empty if not published)
+		c.JSON(http.StatusOK, toPublicArtworkDTO(a))
+		return
 	}
 
-	c.JSON(http.StatusOK, toArtworkDTOFromRevision(a, rev))
+	// draft view: draft if exists, else published
+	c.JSON(http.StatusOK, toArtworkDTOFromRevision(a, pickArtworkRevisionDraftView(a)))
+}
+
+func loadUserArtwork(db *gorm.DB, userID uint, id string) (works.Artwork, error) {
+	var a works.Artwork
+	err := db.
+		Preload("Editions", orderEditions).
+		Preload("Terms", orderTerms).
+		Preload("Terms.I18n").
+		Preload("DraftRevision.Image.Variants").
+		Preload("DraftRevision.I18n").
+		Scopes(withArtworkRecords("DraftRevision")).
+		Preload("PublishedRevision.Image.Variants").
+		Preload("PublishedRevision.I18n").
+		Scopes(withArtworkRecords("PublishedRevision")).
+		First(&a, "id = ? AND owner_type = ? AND user_id = ?", id, works.OwnerUser, userID).Error
+	return a, err
 }
 
 func CreateTemplateSeries(c *gin.Context) {
@@ -887,18 +1272,23 @@ func CreateTemplateSeries(c *gin.Context) {
 			UserID:    nil,
 			IDLocked:  req.IDLocked,
 		}
+		if req.SortIndex != nil {
+			s.SortIndex = *req.SortIndex
+		}
 		if err := tx.Create(&s).Error; err != nil {
 			return err
 		}
 
-		// 2) create draft revision
+		// 2) create draft revision (templates are live right away)
+		now := time.Now()
 		dr := works.SeriesRevision{
-			SeriesID: s.ID,
+			SeriesID:    s.ID,
+			PublishedAt: &now,
 		}
 
 		// image goes on revision
 		if req.Image != nil {
-			imgID, err := upsertImage(tx, nil, req.Image.OriginalPath, req.Image.WebpPath, req.Image.AvifPath)
+			imgID, err := resolveImageInput(tx, nil, nil, req.Image)
 			if err != nil {
 				return err
 			}
@@ -941,6 +1331,10 @@ func CreateTemplateSeries(c *gin.Context) {
 	})
 
 	if err != nil {
+		if isImageInputError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create template series", "details": err.Error()})
 	}
 }
@@ -978,18 +1372,21 @@ func CreateTemplateArtwork(c *gin.Context) {
 		if err := tx.Create(&a).Error; err != nil {
 			return err
 		}
+		if req.EditionSize != 0 || req.ArtistProofs != 0 {
+			if err := syncEditions(tx, a.ID, req.EditionSize, req.ArtistProofs); err != nil {
+				return err
+			}
+		}
 
-		// 2) create draft revision with fields
+		// 2) create draft revision with fields (templates are live right away)
+		now := time.Now()
 		dr := works.ArtworkRevision{
-			ArtworkID: a.ID,
-			Year:      req.Year,
-			Medium:    req.Medium,
-			SizeCM:    req.SizeCM,
-			Price:     req.Price,
+			ArtworkID:   a.ID,
+			PublishedAt: &now,
 		}
 
 		if req.Image != nil {
-			imgID, err := upsertImage(tx, nil, req.Image.OriginalPath, req.Image.WebpPath, req.Image.AvifPath)
+			imgID, err := resolveImageInput(tx, nil, nil, req.Image)
 			if err != nil {
 				return err
 			}
@@ -999,6 +1396,17 @@ func CreateTemplateArtwork(c *gin.Context) {
 		if err := tx.Create(&dr).Error; err != nil {
 			return err
 		}
+		fieldUpdates, err := artworkFieldUpdates(req.fields())
+		if err != nil {
+			return err
+		}
+		if len(fieldUpdates) > 0 {
+			if err := tx.Model(&works.ArtworkRevision{}).
+				Where("id = ?", dr.ID).
+				Updates(fieldUpdates).Error; err != nil {
+				return err
+			}
+		}
 
 		for lang, v := range req.I18n {
 			row := works.ArtworkI18nRevision{
@@ -1012,6 +1420,9 @@ func CreateTemplateArtwork(c *gin.Context) {
 				return err
 			}
 		}
+		if err := replaceArtworkRecords(tx, dr.ID, req.records()); err != nil {
+			return err
+		}
 
 		// 3) point identity to draft revision (and optionally published)
 		updates := map[string]interface{}{
@@ -1035,6 +1446,14 @@ func CreateTemplateArtwork(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Template series not found"})
 			return
 		}
+		if err.Error() == "edition in use" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot remove editions that are reserved, sold or gifted"})
+			return
+		}
+		if isImageInputError(err) || isFieldInputError(err) || isEditionInputError(err) || isRecordInputError(err) || err.Error() == "term not found" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create template artwork", "details": err.Error()})
 	}
 }
@@ -1061,49 +1480,9 @@ func DeleteAllArtworksOfSeries(c *gin.Context) {
 			return fmt.Errorf("locked")
 		}
 
-		// load artworks (only fields we need)
-		var items []works.Artwork
-		if err := tx.Select("id", "draft_revision_id", "published_revision_id").
-			Where("series_id = ? AND owner_type = ? AND user_id = ?", s.ID, works.OwnerUser, userID).
-			Find(&items).Error; err != nil {
-			return err
-		}
-
-		if len(items) == 0 {
-			c.JSON(http.StatusOK, gin.H{"status": "deleted", "count": 0})
-			return nil
-		}
-
-		artworkIDs := make([]string, 0, len(items))
-		revisionIDs := make([]string, 0, len(items)*2)
-
-		// collect ids
-		for _, a := range items {
-			artworkIDs = append(artworkIDs, a.ID)
-			if a.DraftRevisionID != nil && *a.DraftRevisionID != "" {
-				revisionIDs = append(revisionIDs, *a.DraftRevisionID)
-			}
-			if a.PublishedRevisionID != nil && *a.PublishedRevisionID != "" {
-				revisionIDs = append(revisionIDs, *a.PublishedRevisionID)
-			}
-		}
-
-		// delete i18n rows for those revisions
-		if len(revisionIDs) > 0 {
-			if err := tx.Where("artwork_revision_id IN ?", revisionIDs).
-				Delete(&works.ArtworkI18nRevision{}).Error; err != nil {
-				return err
-			}
-
-			// delete revision rows
-			if err := tx.Where("id IN ?", revisionIDs).
-				Delete(&works.ArtworkRevision{}).Error; err != nil {
-				return err
-			}
-		}
-
-		// delete artwork identity rows
-		res := tx.Where("id IN ?", artworkIDs).Delete(&works.Artwork{})
+		// move artwork identity rows to the trash; revisions stay until purge
+		res := tx.Where("series_id = ? AND owner_type = ? AND user_id = ?", s.ID, works.OwnerUser, userID).
+			Delete(&works.Artwork{})
 		if res.Error != nil {
 			return res.Error
 		}
@@ -1220,13 +1599,24 @@ func BulkDiscardArtworkDrafts(c *gin.Context) {
 			return err
 		}
 
-		// Optional cleanup of orphan draft revisions (+i18n)
+		// Optional cleanup of orphan draft revisions (+i18n, records); published history stays
+		var unpublishedDraftRevIDs []string
 		if len(orphanDraftRevIDs) > 0 {
-			if err := tx.Where("artwork_revision_id IN ?", orphanDraftRevIDs).
+			if err := tx.Model(&works.ArtworkRevision{}).
+				Where("id IN ? AND published_at IS NULL", orphanDraftRevIDs).
+				Pluck("id", &unpublishedDraftRevIDs).Error; err != nil {
+				return err
+			}
+		}
+		if len(unpublishedDraftRevIDs) > 0 {
+			if err := tx.Where("artwork_revision_id IN ?", unpublishedDraftRevIDs).
 				Delete(&works.ArtworkI18nRevision{}).Error; err != nil {
 				return err
 			}
-			if err := tx.Where("id IN ?", orphanDraftRevIDs).
+			if err := deleteArtworkRecords(tx, unpublishedDraftRevIDs); err != nil {
+				return err
+			}
+			if err := tx.Where("id IN ?", unpublishedDraftRevIDs).
 				Delete(&works.ArtworkRevision{}).Error; err != nil {
 				return err
 			}
@@ -0,0 +1,349 @@
+package works
+
+import (
+	"fmt"
+	"net/http"
+
+	"registration-app/database"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ------------------------------
+// GET /artworks/:id/revisions (newest first)
+// ------------------------------
+func ListArtworkRevisions(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var a works.Artwork
+	if err := userArtworksQuery(database.DB, userID).First(&a, "id = ?", c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load artwork"})
+		return
+	}
+
+	var revs []works.ArtworkRevision
+	if err := database.DB.
+		Preload("I18n").
+		Preload("Image.Variants").
+		Where("artwork_id = ?", a.ID).
+		Order("created_at DESC").
+		Find(&revs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load revisions"})
+		return
+	}
+
+	out := RevisionListDTO{Revisions: make([]RevisionSummaryDTO, 0, len(revs))}
+	for _, r := range revs {
+		out.Revisions = append(out.Revisions, toArtworkRevisionSummary(a, r))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ------------------------------
+// GET /artworks/:id/revisions/diff?from=<rev|published>&to=<rev|draft>
+// defaults: from=published, to=draft (draft view falls back to published)
+// ------------------------------
+func DiffArtworkRevisions(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var a works.Artwork
+	if err := userArtworksQuery(database.DB, userID).First(&a, "id = ?", c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load artwork"})
+		return
+	}
+
+	fromRef := c.DefaultQuery("from", "published")
+	toRef := c.DefaultQuery("to", "draft")
+
+	from, err := loadArtworkRevisionRef(database.DB, a, fromRef)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load revision"})
+		return
+	}
+	to, err := loadArtworkRevisionRef(database.DB, a, toRef)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load revision"})
+		return
+	}
+	// explicit ids must exist; "published"/"draft" may legitimately be empty
+	if (from == nil && !isRevisionAlias(fromRef)) || (to == nil && !isRevisionAlias(toRef)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, RevisionDiffDTO{
+		From:    revisionRefID(from, fromRef),
+		To:      revisionRefID(to, toRef),
+		Changes: diffFields(artworkRevisionFields(from), artworkRevisionFields(to)),
+	})
+}
+
+// ------------------------------
+// POST /artworks/:id/revisions/:rev/restore
+// Copies the revision into a new draft; published state is untouched.
+// ------------------------------
+func RestoreArtworkRevision(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var draftID string
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var a works.Artwork
+		if err := userArtworksQuery(tx, userID).First(&a, "id = ?", c.Param("id")).Error; err != nil {
+			return err
+		}
+		if a.IDLocked {
+			return fmt.Errorf("locked")
+		}
+
+		var rev works.ArtworkRevision
+		if err := tx.Preload("I18n").
+			First(&rev, "id = ? AND artwork_id = ?", c.Param("rev"), a.ID).Error; err != nil {
+			return err
+		}
+
+		dr, err := newArtworkDraftFrom(tx, &a, &rev)
+		if err != nil {
+			return err
+		}
+		draftID = dr.ID
+		return nil
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Artwork or revision not found"})
+			return
+		}
+		if err.Error() == "locked" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Artwork is locked"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore revision", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "restored", "draftRevisionId": draftID})
+}
+
+// ------------------------------
+// GET /series/:id/revisions (newest first)
+// ------------------------------
+func ListSeriesRevisions(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var s works.Series
+	if err := userSeriesQuery(database.DB, userID).First(&s, "id = ?", c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load series"})
+		return
+	}
+
+	var revs []works.SeriesRevision
+	if err := database.DB.
+		Preload("I18n").
+		Preload("Image.Variants").
+		Where("series_id = ?", s.ID).
+		Order("created_at DESC").
+		Find(&revs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load revisions"})
+		return
+	}
+
+	out := RevisionListDTO{Revisions: make([]RevisionSummaryDTO, 0, len(revs))}
+	for _, r := range revs {
+		out.Revisions = append(out.Revisions, toSeriesRevisionSummary(s, r))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ------------------------------
+// GET /series/:id/revisions/diff?from=<rev|published>&to=<rev|draft>
+// ------------------------------
+func DiffSeriesRevisions(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var s works.Series
+	if err := userSeriesQuery(database.DB, userID).First(&s, "id = ?", c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load series"})
+		return
+	}
+
+	fromRef := c.DefaultQuery("from", "published")
+	toRef := c.DefaultQuery("to", "draft")
+
+	from, err := loadSeriesRevisionRef(database.DB, s, fromRef)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load revision"})
+		return
+	}
+	to, err := loadSeriesRevisionRef(database.DB, s, toRef)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load revision"})
+		return
+	}
+	if (from == nil && !isRevisionAlias(fromRef)) || (to == nil && !isRevisionAlias(toRef)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, RevisionDiffDTO{
+		From:    seriesRevisionRefID(from, fromRef),
+		To:      seriesRevisionRefID(to, toRef),
+		Changes: diffFields(seriesRevisionFields(from), seriesRevisionFields(to)),
+	})
+}
+
+// ------------------------------
+// POST /series/:id/revisions/:rev/restore
+// ------------------------------
+func RestoreSeriesRevision(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var draftID string
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var s works.Series
+		if err := userSeriesQuery(tx, userID).First(&s, "id = ?", c.Param("id")).Error; err != nil {
+			return err
+		}
+		if s.IDLocked {
+			return fmt.Errorf("locked")
+		}
+
+		var rev works.SeriesRevision
+		if err := tx.Preload("I18n").
+			First(&rev, "id = ? AND series_id = ?", c.Param("rev"), s.ID).Error; err != nil {
+			return err
+		}
+
+		dr, err := newSeriesDraftFrom(tx, &s, &rev)
+		if err != nil {
+			return err
+		}
+		draftID = dr.ID
+		return nil
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series or revision not found"})
+			return
+		}
+		if err.Error() == "locked" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Series is locked"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore revision", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "restored", "draftRevisionId": draftID})
+}
+
+// ---------- helpers
+
+func isRevisionAlias(ref string) bool {
+	return ref == "draft" || ref == "published"
+}
+
+// loadArtworkRevisionRef resolves "published", "draft" (draft view: falls back
+// to published) or a revision id of a. Returns (nil, nil) for an empty alias.
+func loadArtworkRevisionRef(db *gorm.DB, a works.Artwork, ref string) (*works.ArtworkRevision, error) {
+	id := ref
+	switch ref {
+	case "published":
+		if a.PublishedRevisionID == nil {
+			return nil, nil
+		}
+		id = *a.PublishedRevisionID
+	case "draft":
+		if a.DraftRevisionID != nil {
+			id = *a.DraftRevisionID
+		} else if a.PublishedRevisionID != nil {
+			id = *a.PublishedRevisionID
+		} else {
+			return nil, nil
+		}
+	}
+
+	var rev works.ArtworkRevision
+	if err := db.Preload("I18n").Preload("Image.Variants").Scopes(withArtworkRecords("")).
+		First(&rev, "id = ? AND artwork_id = ?", id, a.ID).Error; err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func loadSeriesRevisionRef(db *gorm.DB, s works.Series, ref string) (*works.SeriesRevision, error) {
+	id := ref
+	switch ref {
+	case "published":
+		if s.PublishedRevisionID == nil {
+			return nil, nil
+		}
+		id = *s.PublishedRevisionID
+	case "draft":
+		if s.DraftRevisionID != nil {
+			id = *s.DraftRevisionID
+		} else if s.PublishedRevisionID != nil {
+			id = *s.PublishedRevisionID
+		} else {
+			return nil, nil
+		}
+	}
+
+	var rev works.SeriesRevision
+	if err := db.Preload("I18n").Preload("Image.Variants").
+		First(&rev, "id = ? AND series_id = ?", id, s.ID).Error; err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func revisionRefID(rev *works.ArtworkRevision, ref string) string {
+	if rev != nil {
+		return rev.ID
+	}
+	return ref
+}
+
+func seriesRevisionRefID(rev *works.SeriesRevision, ref string) string {
+	if rev != nil {
+		return rev.ID
+	}
+	return ref
+}
@@ -0,0 +1,814 @@
+package works
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"registration-app/database"
+	mediaapi "registration-app/internal/api/media"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Import
+	------
+	POST /works/import?mode=dry_run|commit  (multipart)
+	  file    works.json (ExportDTO shape), works.csv (export columns) or a ZIP
+	          holding one of them next to the image files (e.g. an export ZIP)
+	  images  optional ZIP of image files for a json/csv file
+
+	- images are referenced by file name (csv "image" column, json image.original);
+	  an export's images/<image id><ext> also match by image id (csv "image_id"
+	  column, json image.id)
+	- everything is created as drafts through createUserSeries/createUserArtwork,
+	  appended after the existing series; missing tags/categories are created
+	- dry_run (default) runs the whole import in a transaction that is rolled back
+	  and reports row-level errors; commit only writes when there are none
+	- csv: rows are artworks, grouped into series by series_id (or series titles);
+	  artwork_id, state, published, has_draft and availability are ignored;
+	  a row without any artwork field only creates its series
+	- json: the draft view of every series/artwork is imported; edition copies
+	  start available
+*/
+
+const defaultMaxImportBytes int64 = 25 << 20 // Caddy allows 30MB bodies
+
+// MaxImportBytes returns MAX_IMPORT_BYTES or the default.
+func MaxImportBytes() int64 {
+	if v, err := strconv.ParseInt(os.Getenv("MAX_IMPORT_BYTES"), 10, 64); err == nil && v > 0 {
+		return v
+	}
+	return defaultMaxImportBytes
+}
+
+var errImportRollback = errors.New("import rolled back")
+
+type importTermRef struct {
+	Kind string
+	Slug string
+}
+
+type importSeries struct {
+	Ref      string // "line 2" / "series[0]"
+	Row      int    // csv line, 0 for json
+	Req      CreateSeriesRequest
+	Image    string // file name, the bundle entry once resolved
+	ImageID  string // image id of an export
+	Terms    []importTermRef
+	Artworks []importArtwork
+}
+
+type importArtwork struct {
+	Ref     string
+	Row     int
+	Req     CreateArtworkRequest
+	Image   string
+	ImageID string
+	Terms   []importTermRef
+}
+
+type importPlan struct {
+	Series     []importSeries
+	TermLabels map[importTermRef]map[string]string // json only
+	Errors     []ImportErrorDTO
+}
+
+func (p *importPlan) fail(ref string, row int, field, msg string) {
+	p.Errors = append(p.Errors, ImportErrorDTO{Row: row, Ref: ref, Field: field, Error: msg})
+}
+
+type ImportErrorDTO struct {
+	Row   int    `json:"row,omitempty"` // csv line (header = 1)
+	Ref   string `json:"ref"`           // "line 3", "series[0].artworks[2]"
+	Field string `json:"field,omitempty"`
+	Error string `json:"error"`
+}
+
+type ImportResultDTO struct {
+	Mode     string           `json:"mode"` // "dry_run" | "commit"
+	Valid    bool             `json:"valid"`
+	Series   int              `json:"series"`
+	Artworks int              `json:"artworks"`
+	Images   int              `json:"images"`
+	NewTerms int              `json:"newTerms"`
+	Errors   []ImportErrorDTO `json:"errors"`
+
+	SeriesIDs []string `json:"seriesIds,omitempty"` // commit only
+}
+
+// ------------------------------
+// POST /works/import?mode=dry_run|commit
+// ------------------------------
+func ImportWorks(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	mode := c.DefaultQuery("mode", "dry_run")
+	if mode != "dry_run" && mode != "commit" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be dry_run or commit"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportBytes())
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Import too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	plan, bundle, err := readImportFiles(c, fh)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	out := ImportResultDTO{Mode: mode, Errors: []ImportErrorDTO{}}
+	names := checkImportImages(plan, bundle)
+	out.Series = len(plan.Series)
+	for _, s := range plan.Series {
+		out.Artworks += len(s.Artworks)
+	}
+	out.Images = len(names)
+
+	// dry run (also the first pass of a commit, before images are stored)
+	_, newTerms, err := runImport(database.DB, userID, plan, nil, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Import failed", "details": err.Error()})
+		return
+	}
+	out.NewTerms = newTerms
+	out.Errors = append(out.Errors, plan.Errors...)
+	out.Valid = len(out.Errors) == 0
+
+	if mode == "dry_run" {
+		c.JSON(http.StatusOK, out)
+		return
+	}
+	if !out.Valid {
+		c.JSON(http.StatusUnprocessableEntity, out)
+		return
+	}
+
+	// uploads are orphans until the import commits; the image GC sweeps them otherwise
+	imageIDs := map[string]string{}
+	for _, name := range names {
+		rc, err := bundle[name].Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read " + name})
+			return
+		}
+		img, err := mediaapi.StoreImage(c.Request.Context(), database.DB, userID, rc, path.Base(name))
+		rc.Close()
+		if err != nil {
+			if status, msg, ok := mediaapi.ImageInputError(err); ok {
+				c.JSON(status, gin.H{"error": msg + ": " + name})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store image " + name, "details": err.Error()})
+			return
+		}
+		imageIDs[name] = img.ID
+	}
+
+	seriesIDs, _, err := runImport(database.DB, userID, plan, imageIDs, true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Import failed", "details": err.Error()})
+		return
+	}
+	if len(plan.Errors) > 0 {
+		out.Errors = plan.Errors
+		out.Valid = false
+		c.JSON(http.StatusUnprocessableEntity, out)
+		return
+	}
+	out.SeriesIDs = seriesIDs
+	c.JSON(http.StatusCreated, out)
+}
+
+func isImportInputError(err error) bool {
+	return isImageInputError(err) || isFieldInputError(err) || isEditionInputError(err) ||
+		isRecordInputError(err) || err.Error() == "term not found"
+}
+
+// runImport creates the plan in one transaction; row errors are added to the
+// plan and roll everything back, as does commit=false. Without imageIDs no
+// images are attached.
+func runImport(db *gorm.DB, userID uint, plan *importPlan, imageIDs map[string]string, commit bool) ([]string, int, error) {
+	var seriesIDs []string
+	newTerms := 0
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		termIDs, created, err := resolveImportTerms(tx, userID, plan)
+		if err != nil {
+			return err
+		}
+		newTerms = created
+
+		var next int
+		if err := userSeriesQuery(tx, userID).
+			Select("COALESCE(MAX(sort_index), -1) + 1").
+			Scan(&next).Error; err != nil {
+			return err
+		}
+
+		for i, is := range plan.Series {
+			req := is.Req
+			sortIndex := next + i
+			req.SortIndex = &sortIndex
+			req.TermIDs = importTermIDs(is.Terms, termIDs)
+			if id, ok := imageIDs[is.Image]; ok {
+				req.Image = &ImageInput{ID: &id}
+			}
+
+			var s works.Series
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				var err error
+				s, err = createUserSeries(tx, userID, req)
+				return err
+			})
+			if err != nil {
+				if !isImportInputError(err) {
+					return err
+				}
+				plan.fail(is.Ref, is.Row, "", err.Error())
+				continue
+			}
+			seriesIDs = append(seriesIDs, s.ID)
+
+			for j, ia := range is.Artworks {
+				req := ia.Req
+				sortIndex := j
+				req.SortIndex = &sortIndex
+				req.TermIDs = importTermIDs(ia.Terms, termIDs)
+				if id, ok := imageIDs[ia.Image]; ok {
+					req.Image = &ImageInput{ID: &id}
+				}
+
+				err := tx.Transaction(func(tx *gorm.DB) error {
+					_, err := createUserArtwork(tx, userID, s.ID, req)
+					return err
+				})
+				if err != nil {
+					if !isImportInputError(err) {
+						return err
+					}
+					plan.fail(ia.Ref, ia.Row, "", err.Error())
+				}
+			}
+		}
+
+		if len(plan.Errors) > 0 || !commit {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err == errImportRollback {
+		return nil, newTerms, nil
+	}
+	return seriesIDs, newTerms, err
+}
+
+// resolveImportTerms maps every referenced tag/category to a term of userID,
+// creating missing ones (labels from the json terms list, if any).
+func resolveImportTerms(tx *gorm.DB, userID uint, plan *importPlan) (map[importTermRef]string, int, error) {
+	ids := map[importTermRef]string{}
+
+	var existing []works.Term
+	if err := userTermsQuery(tx, userID).Find(&existing).Error; err != nil {
+		return nil, 0, err
+	}
+	for _, t := range existing {
+		ids[importTermRef{Kind: t.Kind, Slug: t.Slug}] = t.ID
+	}
+
+	created := 0
+	add := func(refs []importTermRef) error {
+		for _, ref := range refs {
+			if _, ok := ids[ref]; ok {
+				continue
+			}
+			t := works.Term{UserID: userID, Kind: ref.Kind, Slug: ref.Slug}
+			for lang, label := range plan.TermLabels[ref] {
+				t.I18n = append(t.I18n, works.TermI18n{Lang: lang, Label: label})
+			}
+			if err := tx.Create(&t).Error; err != nil {
+				return err
+			}
+			ids[ref] = t.ID
+			created++
+		}
+		return nil
+	}
+	for _, s := range plan.Series {
+		if err := add(s.Terms); err != nil {
+			return nil, 0, err
+		}
+		for _, a := range s.Artworks {
+			if err := add(a.Terms); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return ids, created, nil
+}
+
+func importTermIDs(refs []importTermRef, ids map[importTermRef]string) []string {
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		out = append(out, ids[ref])
+	}
+	return out
+}
+
+// ---------- files
+
+// readImportFiles parses the uploaded catalogue and collects the bundled
+// images (ZIP entries by path).
+func readImportFiles(c *gin.Context, fh *multipart.FileHeader) (*importPlan, map[string]*zip.File, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file")
+	}
+	defer f.Close()
+
+	bundle := map[string]*zip.File{}
+	var plan *importPlan
+
+	switch strings.ToLower(path.Ext(fh.Filename)) {
+	case ".json":
+		plan, err = parseImportJSON(f)
+	case ".csv":
+		plan, err = parseImportCSV(f)
+	case ".zip":
+		zr, err := zip.NewReader(f, fh.Size)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid zip")
+		}
+		var catalogue *zip.File
+		for _, zf := range zr.File {
+			if !importZIPEntry(zf) {
+				continue
+			}
+			switch strings.ToLower(path.Base(zf.Name)) {
+			case "works.json":
+				catalogue = zf
+			case "works.csv":
+				if catalogue == nil {
+					catalogue = zf
+				}
+			case "manifest.json":
+			default:
+				bundle[zf.Name] = zf
+			}
+		}
+		if catalogue == nil {
+			return nil, nil, fmt.Errorf("zip contains no works.json or works.csv")
+		}
+		// the header size can lie; the limit reader bounds what is inflated
+		max := MaxImportBytes()
+		if int64(catalogue.UncompressedSize64) > max {
+			return nil, nil, fmt.Errorf("%s too large", path.Base(catalogue.Name))
+		}
+		rc, err := catalogue.Open()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid zip")
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(io.LimitReader(rc, max+1))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid zip")
+		}
+		if int64(len(data)) > max {
+			return nil, nil, fmt.Errorf("%s too large", path.Base(catalogue.Name))
+		}
+		if strings.HasSuffix(strings.ToLower(catalogue.Name), ".json") {
+			plan, err = parseImportJSON(bytes.NewReader(data))
+		} else {
+			plan, err = parseImportCSV(bytes.NewReader(data))
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return plan, bundle, nil
+	default:
+		return nil, nil, fmt.Errorf("file must be .json, .csv or .zip")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ih, err := c.FormFile("images"); err == nil {
+		imf, err := ih.Open()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read images")
+		}
+		defer imf.Close()
+		zr, err := zip.NewReader(imf, ih.Size)
+		if err != nil {
+			return nil, nil, fmt.Errorf("images must be a zip")
+		}
+		for _, zf := range zr.File {
+			if importZIPEntry(zf) {
+				bundle[zf.Name] = zf
+			}
+		}
+	}
+	return plan, bundle, nil
+}
+
+// importZIPEntry skips directories and OS metadata (__MACOSX, dotfiles).
+func importZIPEntry(zf *zip.File) bool {
+	if zf.FileInfo().IsDir() || strings.HasPrefix(zf.Name, "__MACOSX/") {
+		return false
+	}
+	return !strings.HasPrefix(path.Base(zf.Name), ".")
+}
+
+// matchImportImage finds the bundle entry for a file name or an export image id.
+func matchImportImage(bundle map[string]*zip.File, name, imageID string) (string, bool) {
+	base := strings.ToLower(path.Base(name))
+	for entry := range bundle {
+		b := strings.ToLower(path.Base(entry))
+		if (name != "" && b == base) || (imageID != "" && strings.TrimSuffix(b, path.Ext(b)) == strings.ToLower(imageID)) {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// checkImportImages resolves every image reference to a bundle entry and
+// validates the files once; returns the entries in use.
+func checkImportImages(plan *importPlan, bundle map[string]*zip.File) []string {
+	checked := map[string]error{}
+	var names []string
+
+	resolve := func(ref string, row int, name *string, imageID string) {
+		if *name == "" && imageID == "" {
+			return
+		}
+		entry, ok := matchImportImage(bundle, *name, imageID)
+		if !ok {
+			plan.fail(ref, row, "image", "image file not found")
+			*name = ""
+			return
+		}
+		err, seen := checked[entry]
+		if !seen {
+			err = validateImportImage(bundle[entry])
+			checked[entry] = err
+			if err == nil {
+				names = append(names, entry)
+			}
+		}
+		if err != nil {
+			plan.fail(ref, row, "image", err.Error())
+			*name = ""
+			return
+		}
+		*name = entry
+	}
+
+	for i := range plan.Series {
+		s := &plan.Series[i]
+		resolve(s.Ref, s.Row, &s.Image, s.ImageID)
+		for j := range s.Artworks {
+			a := &s.Artworks[j]
+			resolve(a.Ref, a.Row, &a.Image, a.ImageID)
+		}
+	}
+	return names
+}
+
+func validateImportImage(zf *zip.File) error {
+	if int64(zf.UncompressedSize64) > mediaapi.MaxUploadBytes() {
+		return fmt.Errorf("file too large")
+	}
+	rc, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, mediaapi.MaxUploadBytes()+1))
+	if err != nil {
+		return err
+	}
+	return mediaapi.ValidateImage(data)
+}
+
+// ---------- json
+
+func parseImportJSON(r io.Reader) (*importPlan, error) {
+	var in ExportDTO
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("invalid json: %v", err)
+	}
+
+	plan := &importPlan{TermLabels: map[importTermRef]map[string]string{}}
+	for _, t := range in.Terms {
+		plan.TermLabels[importTermRef{Kind: t.Kind, Slug: t.Slug}] = t.Labels
+	}
+
+	for i, es := range in.Series {
+		s := importSeries{
+			Ref: fmt.Sprintf("series[%d]", i),
+			Req: CreateSeriesRequest{I18n: map[string]SeriesI18nInput{}},
+		}
+		s.Image, s.ImageID = importJSONImage(es.Draft.Image)
+		for lang, m := range es.Draft.I18n {
+			s.Req.I18n[lang] = SeriesI18nInput{Title: m["title"], DescriptionSerie: m["descriptionSerie"], Year: m["year"]}
+		}
+		s.Terms = importJSONTerms(plan, s.Ref, es.Draft.Terms)
+		checkImportTitles(plan, s.Ref, 0, seriesTitles(s.Req.I18n))
+
+		for j, ea := range es.Artworks {
+			ref := fmt.Sprintf("%s.artworks[%d]", s.Ref, j)
+			a := ea.Draft
+			ia := importArtwork{
+				Ref: ref,
+				Req: CreateArtworkRequest{
+					Sold:        a.Sold,
+					Year:        a.Year,
+					Medium:      a.Medium,
+					SizeCM:      a.SizeCM,
+					Price:       a.Price,
+					Provenance:  []ProvenanceInput{},
+					Exhibitions: []ExhibitionInput{},
+					Literature:  []LiteratureInput{},
+					I18n:        map[string]ArtworkI18nInput{},
+				},
+			}
+			ia.Image, ia.ImageID = importJSONImage(a.Image)
+			req := &ia.Req
+			for lang, m := range a.I18n {
+				req.I18n[lang] = ArtworkI18nInput{Title: m["title"], Description: m["description"], Notes: m["notes"]}
+			}
+			if a.YearRange != nil {
+				from := a.YearRange.From
+				req.YearRange = &YearRangeInput{From: &from, To: a.YearRange.To}
+			}
+			if a.MediumKey != "" {
+				key := a.MediumKey
+				req.MediumKey = &key
+			}
+			if d := a.Dimensions; d != nil {
+				req.Dimensions = &DimensionsInput{Height: d.Height, Width: d.Width, Depth: d.Depth, Unit: d.Unit}
+			}
+			if p := a.PriceDetails; p != nil {
+				req.PriceDetails = &PriceDetailsInput{Amount: p.Amount, Currency: p.Currency, Visibility: p.Visibility}
+			}
+			if a.Edition != nil {
+				req.EditionSize = a.Edition.Size
+				req.ArtistProofs = a.Edition.ArtistProofs
+			}
+			for _, p := range a.Provenance {
+				req.Provenance = append(req.Provenance, ProvenanceInput{
+					Owner: p.Owner, Location: p.Location, DateFrom: p.DateFrom, DateTo: p.DateTo,
+					I18n: importRecordI18n(p.I18n, func(m map[string]string) ProvenanceI18nInput {
+						return ProvenanceI18nInput{Note: m["note"]}
+					}),
+				})
+			}
+			for _, e := range a.Exhibitions {
+				req.Exhibitions = append(req.Exhibitions, ExhibitionInput{
+					Venue: e.Venue, City: e.City, Country: e.Country, DateFrom: e.DateFrom, DateTo: e.DateTo,
+					I18n: importRecordI18n(e.I18n, func(m map[string]string) ExhibitionI18nInput {
+						return ExhibitionI18nInput{Title: m["title"], Note: m["note"]}
+					}),
+				})
+			}
+			for _, l := range a.Literature {
+				req.Literature = append(req.Literature, LiteratureInput{
+					Author: l.Author, Publisher: l.Publisher, Date: l.Date, Pages: l.Pages, URL: l.URL,
+					I18n: importRecordI18n(l.I18n, func(m map[string]string) LiteratureI18nInput {
+						return LiteratureI18nInput{Title: m["title"], Note: m["note"]}
+					}),
+				})
+			}
+			ia.Terms = importJSONTerms(plan, ref, a.Terms)
+			checkImportTitles(plan, ref, 0, artworkTitles(req.I18n))
+			s.Artworks = append(s.Artworks, ia)
+		}
+		plan.Series = append(plan.Series, s)
+	}
+	return plan, nil
+}
+
+func importRecordI18n[T any](in map[string]map[string]string, conv func(map[string]string) T) map[string]T {
+	out := map[string]T{}
+	for lang, m := range in {
+		out[lang] = conv(m)
+	}
+	return out
+}
+
+// importJSONImage returns the file name and image id of an export image ref.
+func importJSONImage(img *ImageRefDTO) (string, string) {
+	if img == nil {
+		return "", ""
+	}
+	return img.Original, img.ID
+}
+
+func importJSONTerms(plan *importPlan, ref string, terms []TermDTO) []importTermRef {
+	var out []importTermRef
+	for _, t := range terms {
+		if !works.ValidTermKind(t.Kind) || t.Slug == "" || works.Slugify(t.Slug) != t.Slug {
+			plan.fail(ref, 0, "terms", fmt.Sprintf("invalid term %q", t.Slug))
+			continue
+		}
+		out = append(out, importTermRef{Kind: t.Kind, Slug: t.Slug})
+	}
+	return out
+}
+
+// ---------- csv
+
+var importEditionRe = regexp.MustCompile(`^(\d+)(?:\s*\+\s*(\d+)\s*AP)?$`)
+
+func parseImportCSV(r io.Reader) (*importPlan, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %v", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))] = i
+	}
+	langs := map[string]bool{}
+	for h := range col {
+		if strings.HasPrefix(h, "title_") {
+			langs[strings.TrimPrefix(h, "title_")] = true
+		}
+	}
+	if len(langs) == 0 {
+		return nil, fmt.Errorf("csv needs at least one title_<lang> column")
+	}
+
+	plan := &importPlan{}
+	seriesByKey := map[string]int{}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %v", err)
+		}
+		line, _ := cr.FieldPos(0)
+		ref := fmt.Sprintf("line %d", line)
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		// series
+		seriesI18n := map[string]SeriesI18nInput{}
+		var titles []string
+		for lang := range langs {
+			if t := get("series_title_" + lang); t != "" {
+				seriesI18n[lang] = SeriesI18nInput{Title: t}
+				titles = append(titles, lang+"="+t)
+			}
+		}
+		key := get("series_id")
+		if key == "" {
+			sort.Strings(titles)
+			key = strings.Join(titles, "\x00")
+		}
+		si, ok := seriesByKey[key]
+		if !ok {
+			s := importSeries{Ref: ref, Row: line, Req: CreateSeriesRequest{I18n: seriesI18n}}
+			checkImportTitles(plan, ref, line, seriesTitles(seriesI18n))
+			plan.Series = append(plan.Series, s)
+			si = len(plan.Series) - 1
+			seriesByKey[key] = si
+		}
+
+		// artwork
+		a := importArtwork{
+			Ref:     ref,
+			Row:     line,
+			Image:   get("image"),
+			ImageID: get("image_id"),
+			Req: CreateArtworkRequest{
+				Year:   get("year"),
+				Medium: get("medium"),
+				SizeCM: get("size_cm"),
+				Price:  get("price"),
+				I18n:   map[string]ArtworkI18nInput{},
+			},
+		}
+		for lang := range langs {
+			t := ArtworkI18nInput{Title: get("title_" + lang), Description: get("description_" + lang), Notes: get("notes_" + lang)}
+			if t != (ArtworkI18nInput{}) {
+				a.Req.I18n[lang] = t
+			}
+		}
+		if len(a.Req.I18n) == 0 && importRowHasNoArtwork(get) {
+			continue // series-only row (empty series in an export)
+		}
+		checkImportTitles(plan, ref, line, artworkTitles(a.Req.I18n))
+
+		if v := get("sold"); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				plan.fail(ref, line, "sold", "invalid sold")
+			}
+			a.Req.Sold = b
+		}
+		if v := get("edition"); v != "" {
+			m := importEditionRe.FindStringSubmatch(v)
+			if m == nil {
+				plan.fail(ref, line, "edition", "invalid edition")
+			} else {
+				a.Req.EditionSize, _ = strconv.Atoi(m[1])
+				a.Req.ArtistProofs, _ = strconv.Atoi(m[2])
+			}
+		}
+		for field, kind := range map[string]string{"tags": works.TermTag, "categories": works.TermCategory} {
+			for _, slug := range strings.FieldsFunc(get(field), func(r rune) bool { return r == ';' || r == ',' }) {
+				slug = works.Slugify(slug)
+				if slug != "" {
+					a.Terms = append(a.Terms, importTermRef{Kind: kind, Slug: slug})
+				}
+			}
+		}
+
+		plan.Series[si].Artworks = append(plan.Series[si].Artworks, a)
+	}
+	return plan, nil
+}
+
+// importArtworkColumns are the non-i18n artwork columns of the csv format.
+var importArtworkColumns = []string{"artwork_id", "year", "medium", "size_cm", "price", "sold", "edition", "tags", "categories", "image", "image_id"}
+
+func importRowHasNoArtwork(get func(string) string) bool {
+	for _, name := range importArtworkColumns {
+		if get(name) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ---------- validation shared by both formats
+
+func seriesTitles(i18n map[string]SeriesI18nInput) map[string]string {
+	out := map[string]string{}
+	for lang, v := range i18n {
+		out[lang] = v.Title
+	}
+	return out
+}
+
+func artworkTitles(i18n map[string]ArtworkI18nInput) map[string]string {
+	out := map[string]string{}
+	for lang, v := range i18n {
+		out[lang] = v.Title
+	}
+	return out
+}
+
+// checkImportTitles mirrors the binding of the create requests: at least one
+// language, each with a title.
+func checkImportTitles(plan *importPlan, ref string, row int, titles map[string]string) {
+	if len(titles) == 0 {
+		plan.fail(ref, row, "title", "title required")
+		return
+	}
+	for lang, t := range titles {
+		if strings.TrimSpace(t) == "" {
+			plan.fail(ref, row, "title_"+lang, "title required")
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package works
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	GET /works paging & sparse loading
+	----------------------------------
+	?limit=20&cursor=<page.nextCursor>  pages over series (seriesOrder); no limit = all series
+	?include=items,terms,records,facets  parts to load (default: all)
+	?fields=title,description            i18n keys kept for series and artworks (default: all)
+	?summary=true                        series only: i18n titles, cover image and artwork counts
+	                                     (facets only with an explicit include=facets)
+
+	The response is always WorksJSONDTO; left-out parts are empty, not missing.
+*/
+
+const worksMaxLimit = 100
+
+var worksIncludes = map[string]bool{"items": true, "terms": true, "records": true, "facets": true}
+
+// i18n keys of SerieDTO and ArtworkItemDTO
+var worksFields = map[string]bool{
+	"title": true, "descriptionSerie": true, "year": true,
+	"description": true, "notes": true,
+}
+
+type worksPage struct {
+	Limit   int
+	Cursor  *seriesCursor
+	Summary bool
+
+	include map[string]bool // nil = all
+	fields  map[string]bool // nil = all
+}
+
+// seriesCursor is the position after the last series of a page.
+type seriesCursor struct {
+	SortIndex int       `json:"s"`
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+func (cur seriesCursor) encode() string {
+	b, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSeriesCursor(s string) (*seriesCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var cur seriesCursor
+	if err := json.Unmarshal(b, &cur); err != nil {
+		return nil, err
+	}
+	if cur.ID == "" {
+		return nil, fmt.Errorf("empty cursor")
+	}
+	return &cur, nil
+}
+
+func parseWorksPage(c *gin.Context) (worksPage, error) {
+	var p worksPage
+
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return p, fmt.Errorf("invalid limit")
+		}
+		if n > worksMaxLimit {
+			n = worksMaxLimit
+		}
+		p.Limit = n
+	}
+	if v := c.Query("cursor"); v != "" {
+		cur, err := decodeSeriesCursor(v)
+		if err != nil {
+			return p, fmt.Errorf("invalid cursor")
+		}
+		p.Cursor = cur
+	}
+	if v := c.Query("summary"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return p, fmt.Errorf("invalid summary")
+		}
+		p.Summary = b
+	}
+
+	if _, ok := c.GetQuery("include"); ok {
+		p.include = map[string]bool{}
+		for _, v := range queryList(c, "include") {
+			if !worksIncludes[v] {
+				return p, fmt.Errorf("invalid include %q", v)
+			}
+			p.include[v] = true
+		}
+	}
+	if _, ok := c.GetQuery("fields"); ok {
+		p.fields = map[string]bool{}
+		for _, v := range queryList(c, "fields") {
+			if !worksFields[v] {
+				return p, fmt.Errorf("invalid field %q", v)
+			}
+			p.fields[v] = true
+		}
+	}
+	if p.Summary && p.fields == nil {
+		p.fields = map[string]bool{"title": true}
+	}
+	return p, nil
+}
+
+func (p worksPage) includes(part string) bool {
+	if p.Summary && (part == "items" || part == "records") {
+		return false
+	}
+	if p.Summary && part == "facets" {
+		return p.include["facets"] // summary listings aggregate only on request
+	}
+	return p.include == nil || p.include[part]
+}
+
+// apply restricts a series query to the page (one extra row tells if there is more).
+func (p worksPage) apply(q *gorm.DB) *gorm.DB {
+	if cur := p.Cursor; cur != nil {
+		q = q.Where(`(series.sort_index > ?
+			OR (series.sort_index = ? AND series.created_at < ?)
+			OR (series.sort_index = ? AND series.created_at = ? AND series.id > ?))`,
+			cur.SortIndex,
+			cur.SortIndex, cur.CreatedAt,
+			cur.SortIndex, cur.CreatedAt, cur.ID)
+	}
+	if p.Limit > 0 {
+		q = q.Limit(p.Limit + 1)
+	}
+	return q
+}
+
+// page trims the extra row and returns the page info (nil without limit).
+func (p worksPage) page(series []works.Series) ([]works.Series, *WorksPageDTO) {
+	if p.Limit == 0 {
+		return series, nil
+	}
+	info := &WorksPageDTO{Limit: p.Limit}
+	if len(series) > p.Limit {
+		series = series[:p.Limit]
+		last := series[len(series)-1]
+		info.HasMore = true
+		info.NextCursor = seriesCursor{SortIndex: last.SortIndex, CreatedAt: last.CreatedAt, ID: last.ID}.encode()
+	}
+	return series, info
+}
+
+func (p worksPage) trimI18n(i18n map[string]map[string]string) {
+	if p.fields == nil {
+		return
+	}
+	for _, fields := range i18n {
+		for k := range fields {
+			if !p.fields[k] {
+				delete(fields, k)
+			}
+		}
+	}
+}
+
+// sparse drops the i18n keys that were not asked for.
+func (p worksPage) sparse(dto *SerieDTO) {
+	p.trimI18n(dto.I18n)
+	for i := range dto.Items {
+		p.trimI18n(dto.Items[i].I18n)
+	}
+}
+
+// seriesCounts counts the artworks of base (a filtered artworks query) per series.
+func seriesCounts(base *gorm.DB, seriesIDs []string) (map[string]*SeriesCountsDTO, error) {
+	out := map[string]*SeriesCountsDTO{}
+	if len(seriesIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		SeriesID  string
+		Artworks  int
+		Published int
+		Sold      int
+	}
+	if err := base.
+		Where("artworks.series_id IN ?", seriesIDs).
+		Select(`artworks.series_id,
+			COUNT(*) AS artworks,
+			COUNT(*) FILTER (WHERE artworks.published_revision_id IS NOT NULL) AS published,
+			COUNT(*) FILTER (WHERE artworks.sold) AS sold`).
+		Group("artworks.series_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		out[r.SeriesID] = &SeriesCountsDTO{Artworks: r.Artworks, Published: r.Published, Sold: r.Sold}
+	}
+	return out, nil
+}
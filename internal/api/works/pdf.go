@@ -0,0 +1,555 @@
+package works
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/media"
+	"registration-app/internal/domain/users"
+	"registration-app/internal/domain/works"
+	"registration-app/internal/infra/imaging"
+	"registration-app/internal/infra/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-pdf/fpdf"
+	"gorm.io/gorm"
+)
+
+/*
+	PDF catalogue
+	-------------
+	POST /works/pdf  { series_id | artwork_ids, lang, view, layout, cover, price_list, title }
+
+	- series_id: the series' artworks in order; artwork_ids: hand-picked, in the given order
+	- view "published" (default) leaves unpublished artworks out; "draft" shows the draft view
+	- layout "page" (one artwork per page, default) or "grid" (2 × 3 per page)
+	- captions: title, year, medium, size in lang (display strings of the revision)
+	- price_list adds a table with the prices (hidden prices stay empty, sold is marked)
+	- at most pdfMaxArtworks artworks, for series_id as well as artwork_ids
+	- images come from storage (the webp derivative closest to pdfImageMaxPx, the
+	  original until derivatives exist; re-encoded as JPEG, imaging.MaxPixels
+	  applies); legacy path-only images are left out
+	- core PDF fonts: text is rendered in Windows-1252, which covers the Latin
+	  languages but not e.g. Cyrillic
+*/
+
+const (
+	pdfMargin       = 20.0
+	pdfPageW        = 210.0
+	pdfPageH        = 297.0
+	pdfImageMaxPx   = 1600
+	pdfJPEGQuality  = 85
+	pdfMaxArtworks  = 200
+	pdfGridColumns  = 2
+	pdfGridRows     = 3
+	pdfGridGap      = 10.0
+	pdfCaptionLineH = 5.0
+)
+
+var pdfLabels = map[string]map[string]string{
+	"en": {"price_list": "Price list", "title": "Title", "year": "Year", "medium": "Medium", "size": "Size", "price": "Price", "sold": "Sold"},
+	"de": {"price_list": "Preisliste", "title": "Titel", "year": "Jahr", "medium": "Technik", "size": "Maße", "price": "Preis", "sold": "Verkauft"},
+	"fr": {"price_list": "Liste de prix", "title": "Titre", "year": "Année", "medium": "Technique", "size": "Dimensions", "price": "Prix", "sold": "Vendu"},
+	"es": {"price_list": "Lista de precios", "title": "Título", "year": "Año", "medium": "Técnica", "size": "Medidas", "price": "Precio", "sold": "Vendido"},
+	"it": {"price_list": "Listino prezzi", "title": "Titolo", "year": "Anno", "medium": "Tecnica", "size": "Misure", "price": "Prezzo", "sold": "Venduto"},
+	"nl": {"price_list": "Prijslijst", "title": "Titel", "year": "Jaar", "medium": "Techniek", "size": "Afmetingen", "price": "Prijs", "sold": "Verkocht"},
+	"pt": {"price_list": "Lista de preços", "title": "Título", "year": "Ano", "medium": "Técnica", "size": "Dimensões", "price": "Preço", "sold": "Vendido"},
+}
+
+func pdfLabel(lang, key string) string {
+	if l, ok := pdfLabels[works.BaseLang(lang)]; ok {
+		return l[key]
+	}
+	return pdfLabels["en"][key]
+}
+
+type CataloguePDFRequest struct {
+	SeriesID   string   `json:"series_id"`
+	ArtworkIDs []string `json:"artwork_ids"` // hand-picked, in this order
+	Lang       string   `json:"lang"`        // default "en"
+	View       string   `json:"view"`        // published (default) | draft
+	Layout     string   `json:"layout"`      // page (default) | grid
+	Cover      *bool    `json:"cover"`       // default true
+	PriceList  bool     `json:"price_list"`
+	Title      string   `json:"title"` // cover title; default: series title
+}
+
+// pdfItem is one artwork as printed.
+type pdfItem struct {
+	Title  string
+	Year   string
+	Medium string
+	Size   string
+	Price  string
+	Sold   bool
+	Image  *media.Image
+}
+
+func (it pdfItem) caption() []string {
+	var lines []string
+	title := it.Title
+	if it.Year != "" {
+		title += ", " + it.Year
+	}
+	lines = append(lines, title)
+	if it.Medium != "" {
+		lines = append(lines, it.Medium)
+	}
+	if it.Size != "" {
+		lines = append(lines, it.Size)
+	}
+	return lines
+}
+
+// ------------------------------
+// POST /works/pdf
+// ------------------------------
+func CataloguePDF(c *gin.Context) {
+	var req CataloguePDFRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if (req.SeriesID == "") == (len(req.ArtworkIDs) == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "series_id or artwork_ids required"})
+		return
+	}
+	if len(req.ArtworkIDs) > pdfMaxArtworks {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "too many artworks (max " + strconv.Itoa(pdfMaxArtworks) + ")"})
+		return
+	}
+	if req.Lang == "" {
+		req.Lang = "en"
+	}
+	if req.View == "" {
+		req.View = "published"
+	}
+	if req.View != "published" && req.View != "draft" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "view must be published or draft"})
+		return
+	}
+	if req.Layout == "" {
+		req.Layout = "page"
+	}
+	if req.Layout != "page" && req.Layout != "grid" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "layout must be page or grid"})
+		return
+	}
+
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	title, cover, items, err := loadPDFItems(database.DB, userID, req)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series or artwork not found"})
+			return
+		}
+		if err == errPDFTooManyArtworks {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load works", "details": err.Error()})
+		return
+	}
+	if req.Title != "" {
+		title = req.Title
+	}
+
+	var artist users.User
+	_ = database.DB.Select("id", "name", "lastname").First(&artist, userID).Error
+	artistName := strings.TrimSpace(artist.Name + " " + artist.Lastname)
+
+	var buf bytes.Buffer
+	if err := renderCataloguePDF(c.Request.Context(), &buf, req, title, artistName, cover, items); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render PDF", "details": err.Error()})
+		return
+	}
+
+	name := works.Slugify(title)
+	if name == "" {
+		name = "catalogue"
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+var errPDFTooManyArtworks = errors.New("too many artworks (max " + strconv.Itoa(pdfMaxArtworks) + ")")
+
+// loadPDFItems returns the cover title and image and the printable artworks.
+// A series with more than pdfMaxArtworks artworks is rejected before loading.
+func loadPDFItems(db *gorm.DB, userID uint, req CataloguePDFRequest) (string, *media.Image, []pdfItem, error) {
+	var (
+		title   string
+		cover   *media.Image
+		artlist []works.Artwork
+	)
+
+	if req.SeriesID != "" {
+		var n int64
+		if err := userArtworksQuery(db, userID).
+			Where("series_id = ?", req.SeriesID).
+			Count(&n).Error; err != nil {
+			return "", nil, nil, err
+		}
+		if n > pdfMaxArtworks {
+			return "", nil, nil, errPDFTooManyArtworks
+		}
+		s, err := loadUserSeries(db, userID, req.SeriesID)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		rev := s.PublishedRevision
+		if req.View == "draft" {
+			rev = pickSeriesRevisionDraftView(s)
+		}
+		if rev != nil {
+			cover = rev.Image
+			title = pickSeriesTitle(rev.I18n, req.Lang)
+		}
+		artlist = s.Items
+	} else {
+		var found []works.Artwork
+		if err := userArtworksQuery(db, userID).
+			Preload("DraftRevision.Image.Variants").
+			Preload("DraftRevision.I18n").
+			Preload("PublishedRevision.Image.Variants").
+			Preload("PublishedRevision.I18n").
+			Where("id IN ?", req.ArtworkIDs).
+			Find(&found).Error; err != nil {
+			return "", nil, nil, err
+		}
+		byID := map[string]works.Artwork{}
+		for _, a := range found {
+			byID[a.ID] = a
+		}
+		for _, id := range req.ArtworkIDs {
+			a, ok := byID[id]
+			if !ok {
+				return "", nil, nil, gorm.ErrRecordNotFound
+			}
+			artlist = append(artlist, a)
+		}
+	}
+
+	items := make([]pdfItem, 0, len(artlist))
+	for _, a := range artlist {
+		rev := a.PublishedRevision
+		if req.View == "draft" {
+			rev = pickArtworkRevisionDraftView(a)
+		}
+		if rev == nil {
+			continue
+		}
+		items = append(items, pdfItem{
+			Title:  pickArtworkTitle(rev.I18n, req.Lang),
+			Year:   rev.YearDisplay(),
+			Medium: rev.MediumDisplay(req.Lang),
+			Size:   rev.SizeDisplay(req.Lang),
+			Price:  rev.PriceDisplay(req.Lang),
+			Sold:   a.Sold,
+			Image:  rev.Image,
+		})
+	}
+
+	if cover == nil {
+		for _, it := range items {
+			if it.Image != nil {
+				cover = it.Image
+				break
+			}
+		}
+	}
+	return title, cover, items, nil
+}
+
+// pickSeriesTitle / pickArtworkTitle: lang, else its base language, else English, else any.
+func pickSeriesTitle(rows []works.SeriesI18nRevision, lang string) string {
+	titles := map[string]string{}
+	for _, t := range rows {
+		titles[t.Lang] = t.Title
+	}
+	return pickLangValue(titles, lang)
+}
+
+func pickArtworkTitle(rows []works.ArtworkI18nRevision, lang string) string {
+	titles := map[string]string{}
+	for _, t := range rows {
+		titles[t.Lang] = t.Title
+	}
+	return pickLangValue(titles, lang)
+}
+
+func pickLangValue(values map[string]string, lang string) string {
+	for _, l := range []string{lang, works.BaseLang(lang), "en"} {
+		if v := values[l]; v != "" {
+			return v
+		}
+	}
+	best := ""
+	for l, v := range values {
+		if v != "" && (best == "" || l < best) {
+			best = l
+		}
+	}
+	return values[best]
+}
+
+// ---------- rendering
+
+func renderCataloguePDF(ctx context.Context, w io.Writer, req CataloguePDFRequest, title, artist string, cover *media.Image, items []pdfItem) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.SetTitle(title, true)
+	pdf.SetAuthor(artist, true)
+	pdf.SetCreator("works", true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // cp1252
+
+	pdf.SetFooterFunc(func() {
+		if pdf.PageNo() == 1 && (req.Cover == nil || *req.Cover) {
+			return
+		}
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 5, strconv.Itoa(pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	images := &pdfImages{ctx: ctx, pdf: pdf, names: map[string]string{}}
+	contentW := pdfPageW - 2*pdfMargin
+
+	// cover
+	if req.Cover == nil || *req.Cover {
+		pdf.AddPage()
+		y := pdfMargin + 20
+		if name := images.register(cover); name != "" {
+			images.draw(name, pdfMargin, y, contentW, 150)
+			y += 160
+		}
+		pdf.SetXY(pdfMargin, y)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Helvetica", "B", 24)
+		pdf.MultiCell(contentW, 10, tr(title), "", "C", false)
+		if artist != "" {
+			pdf.SetFont("Helvetica", "", 14)
+			pdf.Ln(4)
+			pdf.MultiCell(contentW, 7, tr(artist), "", "C", false)
+		}
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.Ln(4)
+		pdf.MultiCell(contentW, 5, strconv.Itoa(time.Now().Year()), "", "C", false)
+	}
+
+	// artworks
+	caption := func(it pdfItem, x, y, width, fontSize float64) {
+		pdf.SetTextColor(0, 0, 0)
+		for i, line := range it.caption() {
+			if i == 0 {
+				pdf.SetFont("Helvetica", "I", fontSize)
+			} else {
+				pdf.SetFont("Helvetica", "", fontSize-1)
+			}
+			pdf.SetXY(x, y)
+			pdf.CellFormat(width, pdfCaptionLineH, tr(pdfFit(pdf, tr, line, width)), "", 0, "L", false, 0, "")
+			y += pdfCaptionLineH
+		}
+	}
+
+	if req.Layout == "grid" {
+		perPage := pdfGridColumns * pdfGridRows
+		cellW := (contentW - pdfGridGap*(pdfGridColumns-1)) / pdfGridColumns
+		cellH := (pdfPageH - 2*pdfMargin - pdfGridGap*(pdfGridRows-1)) / pdfGridRows
+		imageH := cellH - 3*pdfCaptionLineH - 3
+		for i, it := range items {
+			if i%perPage == 0 {
+				pdf.AddPage()
+			}
+			col := i % pdfGridColumns
+			row := (i % perPage) / pdfGridColumns
+			x := pdfMargin + float64(col)*(cellW+pdfGridGap)
+			y := pdfMargin + float64(row)*(cellH+pdfGridGap)
+			if name := images.register(it.Image); name != "" {
+				images.draw(name, x, y, cellW, imageH)
+			}
+			caption(it, x, y+imageH+3, cellW, 9)
+		}
+	} else {
+		imageH := pdfPageH - 2*pdfMargin - 4*pdfCaptionLineH - 10
+		for _, it := range items {
+			pdf.AddPage()
+			if name := images.register(it.Image); name != "" {
+				images.draw(name, pdfMargin, pdfMargin, contentW, imageH)
+			}
+			caption(it, pdfMargin, pdfMargin+imageH+8, contentW, 11)
+		}
+	}
+
+	// price list
+	if req.PriceList {
+		cols := []struct {
+			key   string
+			width float64
+			align string
+		}{
+			{"", 10, "R"}, {"title", 52, "L"}, {"year", 18, "L"}, {"medium", 34, "L"}, {"size", 32, "L"}, {"price", 24, "R"},
+		}
+		header := func() {
+			pdf.SetFont("Helvetica", "B", 9)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.SetX(pdfMargin)
+			for _, col := range cols {
+				label := ""
+				if col.key != "" {
+					label = pdfLabel(req.Lang, col.key)
+				}
+				pdf.CellFormat(col.width, 7, tr(label), "B", 0, col.align, false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+
+		pdf.AddPage()
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.CellFormat(contentW, 10, tr(pdfLabel(req.Lang, "price_list")), "", 1, "L", false, 0, "")
+		pdf.Ln(2)
+		header()
+		pdf.SetFont("Helvetica", "", 9)
+		for i, it := range items {
+			if pdf.GetY() > pdfPageH-pdfMargin-7 {
+				pdf.AddPage()
+				header()
+				pdf.SetFont("Helvetica", "", 9)
+			}
+			price := it.Price
+			if it.Sold {
+				price = pdfLabel(req.Lang, "sold")
+			}
+			values := []string{strconv.Itoa(i + 1), it.Title, it.Year, it.Medium, it.Size, price}
+			pdf.SetX(pdfMargin)
+			for j, col := range cols {
+				pdf.CellFormat(col.width, 6, tr(pdfFit(pdf, tr, values[j], col.width-1)), "", 0, col.align, false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+	}
+
+	if len(items) == 0 && pdf.PageCount() == 0 {
+		pdf.AddPage()
+	}
+	return pdf.Output(w)
+}
+
+// pdfFit shortens s with "…" until it fits width in the current font.
+func pdfFit(pdf *fpdf.Fpdf, tr func(string) string, s string, width float64) string {
+	if pdf.GetStringWidth(tr(s)) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && pdf.GetStringWidth(tr(string(r)+"…")) > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "…"
+}
+
+// pdfImages loads images from storage once and registers them as JPEG.
+type pdfImages struct {
+	ctx   context.Context
+	pdf   *fpdf.Fpdf
+	names map[string]string // image id -> registered name ("" = unavailable)
+}
+
+func (p *pdfImages) register(img *media.Image) string {
+	if img == nil {
+		return ""
+	}
+	if name, ok := p.names[img.ID]; ok {
+		return name
+	}
+	p.names[img.ID] = ""
+	key := pdfImageSource(img)
+	if key == "" {
+		return ""
+	}
+
+	r, err := storage.Store.Open(p.ctx, key)
+	if err != nil {
+		return ""
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return ""
+	}
+	decoded, _, err := imaging.Decode(data)
+	if err != nil {
+		return ""
+	}
+	var jpg bytes.Buffer
+	if err := imaging.EncodeJPEG(&jpg, imaging.ResizeToWidth(decoded, pdfImageMaxPx), pdfJPEGQuality); err != nil {
+		return ""
+	}
+
+	name := "img-" + img.ID
+	p.pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "JPG"}, &jpg)
+	if !p.pdf.Ok() {
+		p.pdf.ClearError()
+		return ""
+	}
+	p.names[img.ID] = name
+	return name
+}
+
+// pdfImageSource picks the smallest webp derivative at least pdfImageMaxPx
+// wide (else the widest), falling back to the original until derivatives exist.
+func pdfImageSource(img *media.Image) string {
+	var best *media.ImageVariant
+	for i := range img.Variants {
+		v := &img.Variants[i]
+		if v.StorageKey == "" {
+			continue
+		}
+		switch {
+		case best == nil:
+			best = v
+		case best.Width < pdfImageMaxPx && v.Width > best.Width:
+			best = v
+		case v.Width >= pdfImageMaxPx && v.Width < best.Width:
+			best = v
+		}
+	}
+	if best != nil {
+		return best.StorageKey
+	}
+	if img.StorageKey != nil {
+		return *img.StorageKey
+	}
+	return ""
+}
+
+// draw fits a registered image into the box, centred horizontally.
+func (p *pdfImages) draw(name string, x, y, boxW, boxH float64) {
+	info := p.pdf.GetImageInfo(name)
+	if info == nil {
+		return
+	}
+	w, h := info.Extent()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	scale := boxW / w
+	if h*scale > boxH {
+		scale = boxH / h
+	}
+	w, h = w*scale, h*scale
+	p.pdf.ImageOptions(name, x+(boxW-w)/2, y, w, h, false, fpdf.ImageOptions{ImageType: "JPG"}, 0, "")
+}
@@ -0,0 +1,170 @@
+package works
+
+import (
+	"registration-app/internal/domain/media"
+	"registration-app/internal/domain/works"
+
+	"gorm.io/gorm"
+)
+
+/*
+	Public works
+	------------
+	- What a visitor of the artist's site may see: published series and
+	  published artworks only, no drafts, revision ids or schedules
+	- Prices only when PriceVisibility is "show" ("on request" is a display
+	  label only); no artist notes or per-copy buyer details
+	- Provenance, exhibitions and literature of the published revision: records
+	  are versioned with it, so draft records stay private until published
+	- Used by the public site delivery (internal/api/site/public.go)
+*/
+
+type PublicArtworkDTO struct {
+	ID       string       `json:"id"`
+	SeriesID string       `json:"seriesId"`
+	Image    *ImageRefDTO `json:"image,omitempty"`
+
+	I18n    map[string]map[string]string `json:"i18n"`    // lang -> {"title","description"}
+	Display map[string]map[string]string `json:"display"` // lang -> {"year","medium","size","price"}
+
+	YearRange  *YearRangeDTO    `json:"year_range,omitempty"`
+	Dimensions *DimensionsDTO   `json:"dimensions,omitempty"`
+	Price      *PriceDetailsDTO `json:"price,omitempty"` // visible prices only
+
+	Provenance  []ProvenanceDTO `json:"provenance"`
+	Exhibitions []ExhibitionDTO `json:"exhibitions"`
+	Literature  []LiteratureDTO `json:"literature"`
+	Terms       []TermDTO       `json:"terms"`
+
+	Sold         bool            `json:"sold"`
+	Availability string          `json:"availability"`
+	Edition      *EditionInfoDTO `json:"edition,omitempty"` // counts only
+}
+
+type PublicSeriesDTO struct {
+	ID    string                       `json:"id"`
+	Image *ImageRefDTO                 `json:"image,omitempty"`
+	I18n  map[string]map[string]string `json:"i18n"` // lang -> {"title","descriptionSerie","year"}
+	Terms []TermDTO                    `json:"terms"`
+	Items []PublicArtworkDTO           `json:"items"`
+}
+
+// LoadPublicWorks returns the published works of userID in site order.
+// maxArtworks > 0 caps the number of artworks across all series (limited sites);
+// series left without artworks by the cap are dropped.
+func LoadPublicWorks(db *gorm.DB, userID uint, maxArtworks int) ([]PublicSeriesDTO, error) {
+	var series []works.Series
+	err := userSeriesQuery(db, userID).
+		Where("published_revision_id IS NOT NULL").
+		Preload("PublishedRevision.Image.Variants").
+		Preload("PublishedRevision.I18n").
+		Preload("Terms").
+		Preload("Terms.I18n").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return userArtworksQuery(db, userID).
+				Where("published_revision_id IS NOT NULL").
+				Order("sort_index ASC, created_at ASC")
+		}).
+		Preload("Items.Terms").
+		Preload("Items.Terms.I18n").
+		Preload("Items.Editions", orderEditions).
+		Preload("Items.PublishedRevision.Image.Variants").
+		Preload("Items.PublishedRevision.I18n").
+		Scopes(withArtworkRecords("Items.PublishedRevision")).
+		Order(seriesOrder).
+		Find(&series).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]PublicSeriesDTO, 0, len(series))
+	left := maxArtworks
+	for _, s := range series {
+		dto := toPublicSeriesDTO(s)
+		if maxArtworks > 0 {
+			if left <= 0 {
+				break
+			}
+			if len(dto.Items) > left {
+				dto.Items = dto.Items[:left]
+			}
+			left -= len(dto.Items)
+			if len(dto.Items) == 0 {
+				continue
+			}
+		}
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
+// LoadPublicImages resolves image ids referenced by site blocks. Only images
+// uploaded by userID are returned, unknown ids are left out.
+func LoadPublicImages(db *gorm.DB, userID uint, ids []string) (map[string]*ImageRefDTO, error) {
+	out := map[string]*ImageRefDTO{}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	var imgs []media.Image
+	if err := db.Preload("Variants").
+		Where("id IN ? AND user_id = ?", ids, userID).
+		Find(&imgs).Error; err != nil {
+		return nil, err
+	}
+	for i := range imgs {
+		out[imgs[i].ID] = toImageRefDTO(&imgs[i])
+	}
+	return out, nil
+}
+
+func toPublicSeriesDTO(s works.Series) PublicSeriesDTO {
+	dto := SerieDTO{I18n: map[string]map[string]string{}}
+	if rev := s.PublishedRevision; rev != nil {
+		dto = toSerieDTO_PublishedView(works.Series{ID: s.ID, PublishedRevision: rev})
+	}
+
+	out := PublicSeriesDTO{
+		ID:    s.ID,
+		Image: dto.Image,
+		I18n:  dto.I18n,
+		Terms: toTermDTOs(s.Terms),
+		Items: make([]PublicArtworkDTO, 0, len(s.Items)),
+	}
+	for _, a := range s.Items {
+		out.Items = append(out.Items, toPublicArtworkItem(a))
+	}
+	return out
+}
+
+func toPublicArtworkItem(a works.Artwork) PublicArtworkDTO {
+	full := toPublicArtworkDTO(a)
+
+	out := PublicArtworkDTO{
+		ID:           a.ID,
+		SeriesID:     a.SeriesID,
+		Image:        full.Image,
+		I18n:         map[string]map[string]string{},
+		Display:      full.Display,
+		YearRange:    full.YearRange,
+		Dimensions:   full.Dimensions,
+		Provenance:   full.Provenance,
+		Exhibitions:  full.Exhibitions,
+		Literature:   full.Literature,
+		Terms:        full.Terms,
+		Sold:         a.Sold,
+		Availability: full.Availability,
+	}
+	if full.Edition != nil {
+		ed := *full.Edition
+		ed.Items = nil
+		out.Edition = &ed
+	}
+	for lang, t := range full.I18n {
+		out.I18n[lang] = map[string]string{"title": t["title"], "description": t["description"]}
+	}
+
+	if rev := a.PublishedRevision; rev != nil && rev.PriceVisibility == works.PriceShow && rev.PriceMinor != nil {
+		out.Price = &PriceDetailsDTO{Amount: rev.PriceMinor, Currency: rev.PriceCurrency, Visibility: rev.PriceVisibility}
+	}
+	return out
+}
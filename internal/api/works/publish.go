@@ -0,0 +1,217 @@
+package works
+
+import (
+	"net/http"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// publishSeriesDraft makes the current draft (created from published if missing)
+// the published revision. The previous published revision stays as history.
+func publishSeriesDraft(tx *gorm.DB, s *works.Series) (*works.SeriesRevision, error) {
+	dr, err := ensureDraftSeriesRevision(tx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := tx.Model(&works.SeriesRevision{}).
+		Where("id = ?", dr.ID).
+		Update("published_at", now).Error; err != nil {
+		return nil, err
+	}
+	dr.PublishedAt = &now
+
+	if err := tx.Model(&works.Series{}).
+		Where("id = ?", s.ID).
+		Updates(map[string]interface{}{
+			"published_revision_id": dr.ID,
+			"draft_revision_id":     nil,
+		}).Error; err != nil {
+		return nil, err
+	}
+	s.PublishedRevisionID = &dr.ID
+	s.DraftRevisionID = nil
+
+	return dr, nil
+}
+
+func publishArtworkDraft(tx *gorm.DB, a *works.Artwork) (*works.ArtworkRevision, error) {
+	dr, err := ensureDraftArtworkRevision(tx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := tx.Model(&works.ArtworkRevision{}).
+		Where("id = ?", dr.ID).
+		Update("published_at", now).Error; err != nil {
+		return nil, err
+	}
+	dr.PublishedAt = &now
+
+	// publish = point published_revision_id to draft revision
+	if err := tx.Model(&works.Artwork{}).
+		Where("id = ?", a.ID).
+		Updates(map[string]interface{}{
+			"published_revision_id": dr.ID,
+			"draft_revision_id":     nil, // ✅ important
+		}).Error; err != nil {
+		return nil, err
+	}
+	a.PublishedRevisionID = &dr.ID
+	a.DraftRevisionID = nil
+
+	return dr, nil
+}
+
+// unpublishSeries removes the published pointer. Without a draft the content is
+// kept as draft (the revision itself stays immutable history, see ensureDraft*).
+func unpublishSeries(tx *gorm.DB, s *works.Series) error {
+	updates := map[string]interface{}{
+		"published_revision_id": nil,
+		"unpublish_at":          nil,
+	}
+	if s.DraftRevisionID == nil && s.PublishedRevisionID != nil {
+		updates["draft_revision_id"] = s.PublishedRevisionID
+		s.DraftRevisionID = s.PublishedRevisionID
+	}
+	s.PublishedRevisionID = nil
+	s.UnpublishAt = nil
+
+	return tx.Model(&works.Series{}).Where("id = ?", s.ID).Updates(updates).Error
+}
+
+func unpublishArtwork(tx *gorm.DB, a *works.Artwork) error {
+	updates := map[string]interface{}{
+		"published_revision_id": nil,
+		"unpublish_at":          nil,
+	}
+	if a.DraftRevisionID == nil && a.PublishedRevisionID != nil {
+		updates["draft_revision_id"] = a.PublishedRevisionID
+		a.DraftRevisionID = a.PublishedRevisionID
+	}
+	a.PublishedRevisionID = nil
+	a.UnpublishAt = nil
+
+	return tx.Model(&works.Artwork{}).Where("id = ?", a.ID).Updates(updates).Error
+}
+
+// ------------------------------
+// POST /series/:id/publish-all
+// Publishes the series draft and every artwork draft in one transaction,
+// so visitors never see a half-published series.
+// ------------------------------
+func PublishSeriesWithArtworks(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var report PublishReportDTO
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		report = PublishReportDTO{Items: []PublishReportItemDTO{}}
+
+		// row locks keep the schedule job and concurrent publishes out
+		var s works.Series
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&s, "id = ? AND owner_type = ? AND user_id = ?", c.Param("id"), works.OwnerUser, userID).Error; err != nil {
+			return err
+		}
+		if err := checkSeriesIfMatch(c, tx, &s); err != nil {
+			return err
+		}
+
+		item := PublishReportItemDTO{Kind: "series", ID: s.ID}
+		if reason := publishSkipReason(s.IDLocked, s.DraftRevisionID, s.PublishedRevisionID); reason != "" {
+			item.Status, item.Reason = "skipped", reason
+		} else {
+			rev, err := publishSeriesDraft(tx, &s)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&works.Series{}).Where("id = ?", s.ID).Update("publish_at", nil).Error; err != nil {
+				return err
+			}
+			item.Status, item.RevisionID = "published", &rev.ID
+		}
+		report.add(item)
+
+		var artworks []works.Artwork
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("series_id = ? AND owner_type = ? AND user_id = ?", s.ID, works.OwnerUser, userID).
+			Order("sort_index ASC, created_at ASC").
+			Find(&artworks).Error; err != nil {
+			return err
+		}
+
+		for i := range artworks {
+			a := &artworks[i]
+			item := PublishReportItemDTO{Kind: "artwork", ID: a.ID}
+			if reason := publishSkipReason(a.IDLocked, a.DraftRevisionID, a.PublishedRevisionID); reason != "" {
+				item.Status, item.Reason = "skipped", reason
+				report.add(item)
+				continue
+			}
+
+			rev, err := publishArtworkDraft(tx, a)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&works.Artwork{}).Where("id = ?", a.ID).Update("publish_at", nil).Error; err != nil {
+				return err
+			}
+			item.Status, item.RevisionID = "published", &rev.ID
+			report.add(item)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
+		if err == errVersionMismatch {
+			respondSeriesVersionMismatch(c, database.DB, userID, c.Param("id"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to publish series", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// publishSkipReason explains why an item is left alone by publish-all ("" = publish).
+func publishSkipReason(locked bool, draftID, publishedID *string) string {
+	hasDraft := draftID != nil && *draftID != ""
+	hasPub := publishedID != nil && *publishedID != ""
+	switch {
+	case locked:
+		return "locked"
+	case hasDraft && hasPub && *draftID == *publishedID:
+		return "no_changes"
+	case !hasDraft && hasPub:
+		return "no_changes"
+	case !hasDraft:
+		return "empty"
+	}
+	return ""
+}
+
+func (r *PublishReportDTO) add(item PublishReportItemDTO) {
+	if item.Status == "published" {
+		r.Published++
+	} else {
+		r.Skipped++
+	}
+	r.Items = append(r.Items, item)
+}
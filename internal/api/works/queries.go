@@ -6,6 +6,10 @@ import (
 	"gorm.io/gorm"
 )
 
+// seriesOrder is the display order of series in every view; id makes it
+// total for the GET /works cursor.
+const seriesOrder = "sort_index ASC, created_at DESC, id ASC"
+
 func userSeriesQuery(db *gorm.DB, userID uint) *gorm.DB {
 	return db.Model(&works.Series{}).
 		Where("owner_type = ? AND user_id = ?", works.OwnerUser, userID)
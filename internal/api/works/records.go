@@ -0,0 +1,343 @@
+package works
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"registration-app/internal/domain/works"
+
+	"gorm.io/gorm"
+)
+
+// Provenance / exhibitions / literature hang off the revision (like its i18n):
+// create/update replace the draft's lists, cloneArtworkRevision copies them and
+// the published view only ever shows the published revision's rows.
+
+// artworkRecordsInput: nil = not sent (keep), empty = clear.
+type artworkRecordsInput struct {
+	Provenance  *[]ProvenanceInput
+	Exhibitions *[]ExhibitionInput
+	Literature  *[]LiteratureInput
+}
+
+func (r CreateArtworkRequest) records() artworkRecordsInput {
+	return artworkRecordsInput{Provenance: &r.Provenance, Exhibitions: &r.Exhibitions, Literature: &r.Literature}
+}
+
+func (r UpdateArtworkRequest) records() artworkRecordsInput {
+	return artworkRecordsInput{Provenance: r.Provenance, Exhibitions: r.Exhibitions, Literature: r.Literature}
+}
+
+var partialDateRe = regexp.MustCompile(`^\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\d|3[01]))?)?$`)
+
+func validDateRange(from, to string) bool {
+	if (from != "" && !partialDateRe.MatchString(from)) || (to != "" && !partialDateRe.MatchString(to)) {
+		return false
+	}
+	// partial dates compare as text; only the common prefix is meaningful
+	if from != "" && to != "" {
+		n := len(from)
+		if len(to) < n {
+			n = len(to)
+		}
+		return to[:n] >= from[:n]
+	}
+	return true
+}
+
+func (in artworkRecordsInput) validate() error {
+	if in.Provenance != nil {
+		for _, p := range *in.Provenance {
+			if strings.TrimSpace(p.Owner) == "" {
+				return fmt.Errorf("provenance owner required")
+			}
+			if !validDateRange(p.DateFrom, p.DateTo) {
+				return fmt.Errorf("invalid record date")
+			}
+		}
+	}
+	if in.Exhibitions != nil {
+		for _, e := range *in.Exhibitions {
+			if strings.TrimSpace(e.Venue) == "" {
+				return fmt.Errorf("exhibition venue required")
+			}
+			if !validDateRange(e.DateFrom, e.DateTo) {
+				return fmt.Errorf("invalid record date")
+			}
+		}
+	}
+	if in.Literature != nil {
+		for _, l := range *in.Literature {
+			hasTitle := false
+			for _, t := range l.I18n {
+				if strings.TrimSpace(t.Title) != "" {
+					hasTitle = true
+				}
+			}
+			if !hasTitle {
+				return fmt.Errorf("literature title required")
+			}
+			if !validDateRange(l.Date, "") {
+				return fmt.Errorf("invalid record date")
+			}
+		}
+	}
+	return nil
+}
+
+func isRecordInputError(err error) bool {
+	switch err.Error() {
+	case "provenance owner required", "exhibition venue required", "literature title required", "invalid record date":
+		return true
+	}
+	return false
+}
+
+// replaceArtworkRecords writes the sent lists onto revision revID.
+func replaceArtworkRecords(tx *gorm.DB, revID string, in artworkRecordsInput) error {
+	if err := in.validate(); err != nil {
+		return err
+	}
+
+	if in.Provenance != nil {
+		if err := deleteProvenance(tx, []string{revID}); err != nil {
+			return err
+		}
+		for i, p := range *in.Provenance {
+			row := works.ArtworkProvenanceRevision{
+				ArtworkRevisionID: revID,
+				SortIndex:         i,
+				Owner:             p.Owner,
+				Location:          p.Location,
+				DateFrom:          p.DateFrom,
+				DateTo:            p.DateTo,
+			}
+			for lang, t := range p.I18n {
+				row.I18n = append(row.I18n, works.ArtworkProvenanceI18nRevision{Lang: lang, Note: t.Note})
+			}
+			if err := tx.Create(&row).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if in.Exhibitions != nil {
+		if err := deleteExhibitions(tx, []string{revID}); err != nil {
+			return err
+		}
+		for i, e := range *in.Exhibitions {
+			row := works.ArtworkExhibitionRevision{
+				ArtworkRevisionID: revID,
+				SortIndex:         i,
+				Venue:             e.Venue,
+				City:              e.City,
+				Country:           e.Country,
+				DateFrom:          e.DateFrom,
+				DateTo:            e.DateTo,
+			}
+			for lang, t := range e.I18n {
+				row.I18n = append(row.I18n, works.ArtworkExhibitionI18nRevision{Lang: lang, Title: t.Title, Note: t.Note})
+			}
+			if err := tx.Create(&row).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if in.Literature != nil {
+		if err := deleteLiterature(tx, []string{revID}); err != nil {
+			return err
+		}
+		for i, l := range *in.Literature {
+			row := works.ArtworkLiteratureRevision{
+				ArtworkRevisionID: revID,
+				SortIndex:         i,
+				Author:            l.Author,
+				Publisher:         l.Publisher,
+				Date:              l.Date,
+				Pages:             l.Pages,
+				URL:               l.URL,
+			}
+			for lang, t := range l.I18n {
+				row.I18n = append(row.I18n, works.ArtworkLiteratureI18nRevision{Lang: lang, Title: t.Title, Note: t.Note})
+			}
+			if err := tx.Create(&row).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// copyArtworkRecords clones all records (+i18n) of revision srcID into dstID.
+func copyArtworkRecords(tx *gorm.DB, srcID, dstID string) error {
+	var prov []works.ArtworkProvenanceRevision
+	if err := tx.Preload("I18n").Where("artwork_revision_id = ?", srcID).Find(&prov).Error; err != nil {
+		return err
+	}
+	for _, p := range prov {
+		row := works.ArtworkProvenanceRevision{
+			ArtworkRevisionID: dstID,
+			SortIndex:         p.SortIndex,
+			Owner:             p.Owner,
+			Location:          p.Location,
+			DateFrom:          p.DateFrom,
+			DateTo:            p.DateTo,
+		}
+		for _, t := range p.I18n {
+			row.I18n = append(row.I18n, works.ArtworkProvenanceI18nRevision{Lang: t.Lang, Note: t.Note})
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+	}
+
+	var exh []works.ArtworkExhibitionRevision
+	if err := tx.Preload("I18n").Where("artwork_revision_id = ?", srcID).Find(&exh).Error; err != nil {
+		return err
+	}
+	for _, e := range exh {
+		row := works.ArtworkExhibitionRevision{
+			ArtworkRevisionID: dstID,
+			SortIndex:         e.SortIndex,
+			Venue:             e.Venue,
+			City:              e.City,
+			Country:           e.Country,
+			DateFrom:          e.DateFrom,
+			DateTo:            e.DateTo,
+		}
+		for _, t := range e.I18n {
+			row.I18n = append(row.I18n, works.ArtworkExhibitionI18nRevision{Lang: t.Lang, Title: t.Title, Note: t.Note})
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+	}
+
+	var lit []works.ArtworkLiteratureRevision
+	if err := tx.Preload("I18n").Where("artwork_revision_id = ?", srcID).Find(&lit).Error; err != nil {
+		return err
+	}
+	for _, l := range lit {
+		row := works.ArtworkLiteratureRevision{
+			ArtworkRevisionID: dstID,
+			SortIndex:         l.SortIndex,
+			Author:            l.Author,
+			Publisher:         l.Publisher,
+			Date:              l.Date,
+			Pages:             l.Pages,
+			URL:               l.URL,
+		}
+		for _, t := range l.I18n {
+			row.I18n = append(row.I18n, works.ArtworkLiteratureI18nRevision{Lang: t.Lang, Title: t.Title, Note: t.Note})
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteArtworkRecords removes all records (+i18n) of the given revisions.
+func deleteArtworkRecords(tx *gorm.DB, revIDs []string) error {
+	if len(revIDs) == 0 {
+		return nil
+	}
+	if err := deleteProvenance(tx, revIDs); err != nil {
+		return err
+	}
+	if err := deleteExhibitions(tx, revIDs); err != nil {
+		return err
+	}
+	return deleteLiterature(tx, revIDs)
+}
+
+func deleteProvenance(tx *gorm.DB, revIDs []string) error {
+	sub := tx.Model(&works.ArtworkProvenanceRevision{}).Select("id").Where("artwork_revision_id IN ?", revIDs)
+	if err := tx.Where("artwork_provenance_revision_id IN (?)", sub).Delete(&works.ArtworkProvenanceI18nRevision{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("artwork_revision_id IN ?", revIDs).Delete(&works.ArtworkProvenanceRevision{}).Error
+}
+
+func deleteExhibitions(tx *gorm.DB, revIDs []string) error {
+	sub := tx.Model(&works.ArtworkExhibitionRevision{}).Select("id").Where("artwork_revision_id IN ?", revIDs)
+	if err := tx.Where("artwork_exhibition_revision_id IN (?)", sub).Delete(&works.ArtworkExhibitionI18nRevision{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("artwork_revision_id IN ?", revIDs).Delete(&works.ArtworkExhibitionRevision{}).Error
+}
+
+func deleteLiterature(tx *gorm.DB, revIDs []string) error {
+	sub := tx.Model(&works.ArtworkLiteratureRevision{}).Select("id").Where("artwork_revision_id IN ?", revIDs)
+	if err := tx.Where("artwork_literature_revision_id IN (?)", sub).Delete(&works.ArtworkLiteratureI18nRevision{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("artwork_revision_id IN ?", revIDs).Delete(&works.ArtworkLiteratureRevision{}).Error
+}
+
+// withArtworkRecords preloads the records (+i18n) of the revision at path
+// ("" = the revision itself, e.g. "Items.DraftRevision").
+func withArtworkRecords(path string) func(*gorm.DB) *gorm.DB {
+	prefix := ""
+	if path != "" {
+		prefix = path + "."
+	}
+	bySortIndex := func(db *gorm.DB) *gorm.DB { return db.Order("sort_index ASC") }
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Preload(prefix+"Provenance", bySortIndex).
+			Preload(prefix+"Provenance.I18n").
+			Preload(prefix+"Exhibitions", bySortIndex).
+			Preload(prefix+"Exhibitions.I18n").
+			Preload(prefix+"Literature", bySortIndex).
+			Preload(prefix + "Literature.I18n")
+	}
+}
+
+// artworkRecordFields flattens the records for revision diffs ("provenance.1.owner", ...).
+func artworkRecordFields(f map[string]string, rev *works.ArtworkRevision) {
+	for i, p := range rev.Provenance {
+		k := "provenance." + strconv.Itoa(i+1) + "."
+		f[k+"owner"] = p.Owner
+		f[k+"location"] = p.Location
+		f[k+"dates"] = dateRangeDisplay(p.DateFrom, p.DateTo)
+		for _, t := range p.I18n {
+			f[k+"i18n."+t.Lang+".note"] = t.Note
+		}
+	}
+	for i, e := range rev.Exhibitions {
+		k := "exhibitions." + strconv.Itoa(i+1) + "."
+		f[k+"venue"] = e.Venue
+		f[k+"city"] = e.City
+		f[k+"country"] = e.Country
+		f[k+"dates"] = dateRangeDisplay(e.DateFrom, e.DateTo)
+		for _, t := range e.I18n {
+			f[k+"i18n."+t.Lang+".title"] = t.Title
+			f[k+"i18n."+t.Lang+".note"] = t.Note
+		}
+	}
+	for i, l := range rev.Literature {
+		k := "literature." + strconv.Itoa(i+1) + "."
+		f[k+"author"] = l.Author
+		f[k+"publisher"] = l.Publisher
+		f[k+"date"] = l.Date
+		f[k+"pages"] = l.Pages
+		f[k+"url"] = l.URL
+		for _, t := range l.I18n {
+			f[k+"i18n."+t.Lang+".title"] = t.Title
+			f[k+"i18n."+t.Lang+".note"] = t.Note
+		}
+	}
+}
+
+func dateRangeDisplay(from, to string) string {
+	if to == "" || to == from {
+		return from
+	}
+	return from + "–" + to
+}
@@ -1,6 +1,9 @@
 package works
 
 import (
+	"sort"
+	"time"
+
 	"registration-app/internal/domain/media"
 	"registration-app/internal/domain/works"
 )
@@ -9,6 +12,20 @@ type ImageRefDTO struct {
 	Original string `json:"original"`
 	Webp     string `json:"webp"`
 	Avif     string `json:"avif"`
+
+	ID     string           `json:"id,omitempty"`
+	Width  int              `json:"width,omitempty"`
+	Height int              `json:"height,omitempty"`
+	Status string           `json:"status,omitempty"` // pending|processing|ready|failed
+	Srcset []ImageSourceDTO `json:"srcset,omitempty"` // ascending width
+}
+
+// ImageSourceDTO is one srcset candidate: "<url> <width>w"
+type ImageSourceDTO struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Type   string `json:"type"` // e.g. "image/webp"
 }
 
 type RevisionMetaDTO struct {
@@ -17,6 +34,13 @@ type RevisionMetaDTO struct {
 	HasDraft            bool    `json:"hasDraft"`
 	DraftRevisionID     *string `json:"draftRevisionId,omitempty"`
 	PublishedRevisionID *string `json:"publishedRevisionId,omitempty"`
+
+	// optimistic concurrency token, also sent as ETag; echo it in If-Match
+	Version string `json:"version"`
+
+	// pending schedules (POST .../publish with publish_at / unpublish_at)
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
 }
 
 type ArtworkItemDTO struct {
@@ -33,6 +57,91 @@ type ArtworkItemDTO struct {
 	Medium string `json:"medium"`
 	SizeCM string `json:"size_cm"`
 	Price  string `json:"price"`
+
+	// structured values (same shape as the request inputs)
+	YearRange    *YearRangeDTO    `json:"year_range,omitempty"`
+	MediumKey    string           `json:"medium_key,omitempty"`
+	Dimensions   *DimensionsDTO   `json:"dimensions,omitempty"`
+	PriceDetails *PriceDetailsDTO `json:"price_details,omitempty"`
+
+	// formatted per language: lang -> {"year","medium","size","price"}
+	Display map[string]map[string]string `json:"display"`
+
+	// revision records, display order; i18n: lang -> {"note"} / {"title","note"}
+	Provenance  []ProvenanceDTO `json:"provenance"`
+	Exhibitions []ExhibitionDTO `json:"exhibitions"`
+	Literature  []LiteratureDTO `json:"literature"`
+
+	Terms []TermDTO `json:"terms"` // own tags and categories (series terms are on the series)
+
+	// available | reserved | sold, derived from the editions for multiples
+	Availability string          `json:"availability"`
+	Edition      *EditionInfoDTO `json:"edition,omitempty"` // multiples only
+}
+
+type ProvenanceDTO struct {
+	Owner    string                       `json:"owner"`
+	Location string                       `json:"location,omitempty"`
+	DateFrom string                       `json:"date_from,omitempty"`
+	DateTo   string                       `json:"date_to,omitempty"`
+	I18n     map[string]map[string]string `json:"i18n"`
+}
+
+type ExhibitionDTO struct {
+	Venue    string                       `json:"venue"`
+	City     string                       `json:"city,omitempty"`
+	Country  string                       `json:"country,omitempty"`
+	DateFrom string                       `json:"date_from,omitempty"`
+	DateTo   string                       `json:"date_to,omitempty"`
+	I18n     map[string]map[string]string `json:"i18n"`
+}
+
+type LiteratureDTO struct {
+	Author    string                       `json:"author,omitempty"`
+	Publisher string                       `json:"publisher,omitempty"`
+	Date      string                       `json:"date,omitempty"`
+	Pages     string                       `json:"pages,omitempty"`
+	URL       string                       `json:"url,omitempty"`
+	I18n      map[string]map[string]string `json:"i18n"`
+}
+
+type EditionInfoDTO struct {
+	Size         int `json:"size"`
+	ArtistProofs int `json:"artistProofs"`
+	Available    int `json:"available"`
+	Reserved     int `json:"reserved"`
+	Sold         int `json:"sold"`
+	Gifted       int `json:"gifted"`
+
+	Items []EditionDTO `json:"items,omitempty"` // owner views only
+}
+
+type EditionDTO struct {
+	ID       string     `json:"id"`
+	Kind     string     `json:"kind"` // "edition" | "ap"
+	Number   int        `json:"number"`
+	Label    string     `json:"label"` // "3/25", "AP 1/3"
+	Status   string     `json:"status"`
+	BuyerRef string     `json:"buyerRef,omitempty"`
+	SoldAt   *time.Time `json:"soldAt,omitempty"`
+}
+
+type YearRangeDTO struct {
+	From int  `json:"from"`
+	To   *int `json:"to,omitempty"`
+}
+
+type DimensionsDTO struct {
+	Height *float64 `json:"height,omitempty"`
+	Width  *float64 `json:"width,omitempty"`
+	Depth  *float64 `json:"depth,omitempty"`
+	Unit   string   `json:"unit"`
+}
+
+type PriceDetailsDTO struct {
+	Amount     *int64 `json:"amount,omitempty"`
+	Currency   string `json:"currency,omitempty"`
+	Visibility string `json:"visibility"`
 }
 
 type SerieDTO struct {
@@ -43,11 +152,70 @@ type SerieDTO struct {
 	Meta RevisionMetaDTO `json:"meta"`
 
 	I18n  map[string]map[string]string `json:"i18n"`
+	Terms []TermDTO                    `json:"terms"`
 	Items []ArtworkItemDTO             `json:"items"`
+
+	Counts *SeriesCountsDTO `json:"counts,omitempty"` // GET /works?summary=true
+}
+
+// SeriesCountsDTO counts the artworks of a series matching the current filters.
+type SeriesCountsDTO struct {
+	Artworks  int `json:"artworks"`
+	Published int `json:"published"`
+	Sold      int `json:"sold"`
 }
 
 type WorksJSONDTO struct {
-	Series []SerieDTO `json:"series"`
+	Series []SerieDTO      `json:"series"`
+	Page   *WorksPageDTO   `json:"page,omitempty"`   // GET /works?limit= only
+	Facets *WorksFacetsDTO `json:"facets,omitempty"` // GET /works only
+}
+
+// WorksPageDTO: pass nextCursor as ?cursor= for the following page.
+type WorksPageDTO struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasMore    bool   `json:"hasMore"`
+}
+
+type TermDTO struct {
+	ID        string            `json:"id"`
+	Kind      string            `json:"kind"` // "tag" | "category"
+	Slug      string            `json:"slug"`
+	SortIndex int               `json:"sort_index"`
+	Labels    map[string]string `json:"labels"` // lang -> label
+}
+
+// WorksFacetsDTO counts the artworks matching the current filters.
+type WorksFacetsDTO struct {
+	Total      int             `json:"total"`
+	Tags       []FacetCountDTO `json:"tags"`
+	Categories []FacetCountDTO `json:"categories"`
+	Mediums    []FacetCountDTO `json:"mediums"`
+	Years      []FacetCountDTO `json:"years"`  // by year_from
+	Sold       []FacetCountDTO `json:"sold"`   // "true" | "false"
+	States     []FacetCountDTO `json:"states"` // "published" | "unpublished" | "draft"
+}
+
+type FacetCountDTO struct {
+	Value  string            `json:"value"` // filter value (slug, medium key, year, ...)
+	Labels map[string]string `json:"labels,omitempty"`
+	Count  int               `json:"count"`
+}
+
+// PublishReportItemDTO is one line of POST /series/:id/publish-all.
+type PublishReportItemDTO struct {
+	Kind       string  `json:"kind"` // "series" | "artwork"
+	ID         string  `json:"id"`
+	Status     string  `json:"status"`           // "published" | "skipped"
+	Reason     string  `json:"reason,omitempty"` // "locked" | "no_changes" | "empty"
+	RevisionID *string `json:"revisionId,omitempty"`
+}
+
+type PublishReportDTO struct {
+	Published int                    `json:"published"`
+	Skipped   int                    `json:"skipped"`
+	Items     []PublishReportItemDTO `json:"items"`
 }
 
 func toImageRefDTO(img *media.Image) *ImageRefDTO {
@@ -62,11 +230,28 @@ func toImageRefDTO(img *media.Image) *ImageRefDTO {
 	if img.AvifPath != nil {
 		avif = *img.AvifPath
 	}
-	return &ImageRefDTO{
+	dto := &ImageRefDTO{
 		Original: img.OriginalPath,
 		Webp:     webp,
 		Avif:     avif,
+		ID:       img.ID,
+		Width:    img.Width,
+		Height:   img.Height,
+		Status:   img.Status,
+	}
+
+	variants := append([]media.ImageVariant(nil), img.Variants...)
+	sort.Slice(variants, func(i, j int) bool { return variants[i].Width < variants[j].Width })
+	for _, v := range variants {
+		dto.Srcset = append(dto.Srcset, ImageSourceDTO{
+			URL:    v.Path,
+			Width:  v.Width,
+			Height: v.Height,
+			Type:   "image/" + v.Format,
+		})
 	}
+
+	return dto
 }
 
 func pickSeriesRevisionDraftView(s works.Series) *works.SeriesRevision {
@@ -96,10 +281,17 @@ func toArtworkDTOFromRevision(a works.Artwork, rev *works.ArtworkRevision) Artwo
 	}
 
 	dto := ArtworkItemDTO{
-		ID:   a.ID,
-		Sold: a.Sold,
-		I18n: i18n,
-		Meta: artworkMeta(a),
+		ID:           a.ID,
+		Sold:         a.Sold,
+		I18n:         i18n,
+		Meta:         artworkMeta(a),
+		Display:      map[string]map[string]string{},
+		Provenance:   []ProvenanceDTO{},
+		Exhibitions:  []ExhibitionDTO{},
+		Literature:   []LiteratureDTO{},
+		Terms:        toTermDTOs(a.Terms),
+		Availability: a.Availability(),
+		Edition:      toEditionInfoDTO(a),
 	}
 
 	if a.IDLocked {
@@ -111,11 +303,144 @@ func toArtworkDTOFromRevision(a works.Artwork, rev *works.ArtworkRevision) Artwo
 		dto.Medium = rev.Medium
 		dto.SizeCM = rev.SizeCM
 		dto.Price = rev.Price
+		applyStructuredFields(&dto, rev)
+		applyArtworkRecords(&dto, rev)
 	}
 
 	return dto
 }
 
+func toTermDTO(t works.Term) TermDTO {
+	labels := map[string]string{}
+	for _, l := range t.I18n {
+		labels[l.Lang] = l.Label
+	}
+	return TermDTO{ID: t.ID, Kind: t.Kind, Slug: t.Slug, SortIndex: t.SortIndex, Labels: labels}
+}
+
+func toTermDTOs(terms []works.Term) []TermDTO {
+	out := make([]TermDTO, 0, len(terms))
+	for _, t := range terms {
+		out = append(out, toTermDTO(t))
+	}
+	return out
+}
+
+func applyArtworkRecords(dto *ArtworkItemDTO, rev *works.ArtworkRevision) {
+	for _, p := range rev.Provenance {
+		i18n := map[string]map[string]string{}
+		for _, t := range p.I18n {
+			i18n[t.Lang] = map[string]string{"note": t.Note}
+		}
+		dto.Provenance = append(dto.Provenance, ProvenanceDTO{
+			Owner:    p.Owner,
+			Location: p.Location,
+			DateFrom: p.DateFrom,
+			DateTo:   p.DateTo,
+			I18n:     i18n,
+		})
+	}
+	for _, e := range rev.Exhibitions {
+		i18n := map[string]map[string]string{}
+		for _, t := range e.I18n {
+			i18n[t.Lang] = map[string]string{"title": t.Title, "note": t.Note}
+		}
+		dto.Exhibitions = append(dto.Exhibitions, ExhibitionDTO{
+			Venue:    e.Venue,
+			City:     e.City,
+			Country:  e.Country,
+			DateFrom: e.DateFrom,
+			DateTo:   e.DateTo,
+			I18n:     i18n,
+		})
+	}
+	for _, l := range rev.Literature {
+		i18n := map[string]map[string]string{}
+		for _, t := range l.I18n {
+			i18n[t.Lang] = map[string]string{"title": t.Title, "note": t.Note}
+		}
+		dto.Literature = append(dto.Literature, LiteratureDTO{
+			Author:    l.Author,
+			Publisher: l.Publisher,
+			Date:      l.Date,
+			Pages:     l.Pages,
+			URL:       l.URL,
+			I18n:      i18n,
+		})
+	}
+}
+
+// toPublicArtworkDTO is the published view of a: published revision only,
+// edition counts without the per-copy buyer details.
+func toPublicArtworkDTO(a works.Artwork) ArtworkItemDTO {
+	dto := toArtworkDTOFromRevision(a, a.PublishedRevision)
+	if dto.Edition != nil {
+		dto.Edition.Items = nil
+	}
+	return dto
+}
+
+func toEditionInfoDTO(a works.Artwork) *EditionInfoDTO {
+	if !a.IsMultiple() {
+		return nil
+	}
+	counts := works.CountEditions(a.Editions)
+	info := &EditionInfoDTO{
+		Size:         a.EditionSize,
+		ArtistProofs: a.ArtistProofs,
+		Available:    counts.Available,
+		Reserved:     counts.Reserved,
+		Sold:         counts.Sold,
+		Gifted:       counts.Gifted,
+		Items:        make([]EditionDTO, 0, len(a.Editions)),
+	}
+	for _, e := range a.Editions {
+		info.Items = append(info.Items, toEditionDTO(e, a.EditionSize, a.ArtistProofs))
+	}
+	return info
+}
+
+func toEditionDTO(e works.ArtworkEdition, editionSize, artistProofs int) EditionDTO {
+	return EditionDTO{
+		ID:       e.ID,
+		Kind:     e.Kind,
+		Number:   e.Number,
+		Label:    e.Label(editionSize, artistProofs),
+		Status:   e.Status,
+		BuyerRef: e.BuyerRef,
+		SoldAt:   e.SoldAt,
+	}
+}
+
+func applyStructuredFields(dto *ArtworkItemDTO, rev *works.ArtworkRevision) {
+	if rev.YearFrom != nil {
+		dto.YearRange = &YearRangeDTO{From: *rev.YearFrom, To: rev.YearTo}
+	}
+	dto.MediumKey = rev.MediumKey
+	if rev.Height != nil || rev.Width != nil || rev.Depth != nil {
+		dto.Dimensions = &DimensionsDTO{Height: rev.Height, Width: rev.Width, Depth: rev.Depth, Unit: rev.DimensionUnit}
+	}
+	if rev.PriceMinor != nil || rev.PriceVisibility != works.PriceShow {
+		dto.PriceDetails = &PriceDetailsDTO{Amount: rev.PriceMinor, Currency: rev.PriceCurrency, Visibility: rev.PriceVisibility}
+	}
+
+	langs := make([]string, 0, len(rev.I18n))
+	for _, t := range rev.I18n {
+		langs = append(langs, t.Lang)
+	}
+	if len(langs) == 0 {
+		langs = append(langs, "en")
+	}
+	for _, lang := range langs {
+		dto.Display[lang] = map[string]string{
+			"year":   rev.YearDisplay(),
+			"medium": rev.MediumDisplay(lang),
+			"size":   rev.SizeDisplay(lang),
+			"price":  rev.PriceDisplay(lang),
+		}
+	}
+}
+
 // Draft view: use draft if exists, else published
 func toSerieDTO_DraftView(s works.Series) SerieDTO {
 	rev := pickSeriesRevisionDraftView(s)
@@ -140,6 +465,7 @@ func toSerieDTO_DraftView(s works.Series) SerieDTO {
 	dto := SerieDTO{
 		ID:    s.ID,
 		I18n:  i18n,
+		Terms: toTermDTOs(s.Terms),
 		Items: items,
 		Meta:  seriesMeta(s),
 	}
@@ -172,12 +498,13 @@ func toSerieDTO_PublishedView(s works.Series) SerieDTO {
 	items := make([]ArtworkItemDTO, 0, len(s.Items))
 	for _, a := range s.Items {
 		// only published fields
-		items = append(items, toArtworkDTOFromRevision(a, a.PublishedRevision))
+		items = append(items, toPublicArtworkDTO(a))
 	}
 
 	dto := SerieDTO{
 		ID:    s.ID,
 		I18n:  i18n,
+		Terms: toTermDTOs(s.Terms),
 		Items: items,
 	}
 
@@ -213,6 +540,9 @@ func seriesMeta(s works.Series) RevisionMetaDTO {
 		HasDraft:            hasDraft,
 		DraftRevisionID:     s.DraftRevisionID,
 		PublishedRevisionID: s.PublishedRevisionID,
+		PublishAt:           s.PublishAt,
+		UnpublishAt:         s.UnpublishAt,
+		Version:             seriesVersion(s),
 	}
 }
 
@@ -238,5 +568,169 @@ func artworkMeta(a works.Artwork) RevisionMetaDTO {
 		HasDraft:            hasDraft,
 		DraftRevisionID:     a.DraftRevisionID,
 		PublishedRevisionID: a.PublishedRevisionID,
+		PublishAt:           a.PublishAt,
+		UnpublishAt:         a.UnpublishAt,
+		Version:             artworkVersion(a),
+	}
+}
+
+// ---------- revision history
+
+type RevisionSummaryDTO struct {
+	ID          string            `json:"id"`
+	State       string            `json:"state"` // "draft" | "published" | "history"
+	Titles      map[string]string `json:"titles"`
+	Image       *ImageRefDTO      `json:"image,omitempty"`
+	PublishedAt *time.Time        `json:"publishedAt,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+type RevisionListDTO struct {
+	Revisions []RevisionSummaryDTO `json:"revisions"`
+}
+
+type FieldChangeDTO struct {
+	Field string `json:"field"` // e.g. "year", "image", "i18n.de.title"
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type RevisionDiffDTO struct {
+	From    string           `json:"from"`
+	To      string           `json:"to"`
+	Changes []FieldChangeDTO `json:"changes"`
+}
+
+func revisionState(revID string, draftID, publishedID *string) string {
+	if publishedID != nil && *publishedID == revID {
+		return "published"
+	}
+	if draftID != nil && *draftID == revID {
+		return "draft"
+	}
+	return "history"
+}
+
+func toArtworkRevisionSummary(a works.Artwork, rev works.ArtworkRevision) RevisionSummaryDTO {
+	titles := map[string]string{}
+	for _, t := range rev.I18n {
+		titles[t.Lang] = t.Title
+	}
+	return RevisionSummaryDTO{
+		ID:          rev.ID,
+		State:       revisionState(rev.ID, a.DraftRevisionID, a.PublishedRevisionID),
+		Titles:      titles,
+		Image:       toImageRefDTO(rev.Image),
+		PublishedAt: rev.PublishedAt,
+		CreatedAt:   rev.CreatedAt,
+		UpdatedAt:   rev.UpdatedAt,
+	}
+}
+
+func toSeriesRevisionSummary(s works.Series, rev works.SeriesRevision) RevisionSummaryDTO {
+	titles := map[string]string{}
+	for _, t := range rev.I18n {
+		titles[t.Lang] = t.Title
+	}
+	return RevisionSummaryDTO{
+		ID:          rev.ID,
+		State:       revisionState(rev.ID, s.DraftRevisionID, s.PublishedRevisionID),
+		Titles:      titles,
+		Image:       toImageRefDTO(rev.Image),
+		PublishedAt: rev.PublishedAt,
+		CreatedAt:   rev.CreatedAt,
+		UpdatedAt:   rev.UpdatedAt,
+	}
+}
+
+// ---------- trash
+
+type TrashItemDTO struct {
+	Kind         string            `json:"kind"` // "series" | "artwork"
+	ID           string            `json:"id"`
+	SeriesID     string            `json:"seriesId,omitempty"`
+	Titles       map[string]string `json:"titles"`
+	ArtworkCount int               `json:"artworkCount,omitempty"` // artworks restored with the series
+	DeletedAt    time.Time         `json:"deletedAt"`
+	PurgeAt      time.Time         `json:"purgeAt"`
+}
+
+type TrashListDTO struct {
+	Items []TrashItemDTO `json:"items"`
+}
+
+func toSeriesTrashItem(s works.Series, artworkCount int, retention time.Duration) TrashItemDTO {
+	titles := map[string]string{}
+	if rev := pickSeriesRevisionDraftView(s); rev != nil {
+		for _, t := range rev.I18n {
+			titles[t.Lang] = t.Title
+		}
 	}
+	return TrashItemDTO{
+		Kind:         "series",
+		ID:           s.ID,
+		Titles:       titles,
+		ArtworkCount: artworkCount,
+		DeletedAt:    s.DeletedAt.Time,
+		PurgeAt:      s.DeletedAt.Time.Add(retention),
+	}
+}
+
+func toArtworkTrashItem(a works.Artwork, retention time.Duration) TrashItemDTO {
+	titles := map[string]string{}
+	if rev := pickArtworkRevisionDraftView(a); rev != nil {
+		for _, t := range rev.I18n {
+			titles[t.Lang] = t.Title
+		}
+	}
+	return TrashItemDTO{
+		Kind:      "artwork",
+		ID:        a.ID,
+		SeriesID:  a.SeriesID,
+		Titles:    titles,
+		DeletedAt: a.DeletedAt.Time,
+		PurgeAt:   a.DeletedAt.Time.Add(retention),
+	}
+}
+
+// ---------- search
+
+type SearchResultDTO struct {
+	Kind     string            `json:"kind"` // "series" | "artwork"
+	ID       string            `json:"id"`
+	SeriesID string            `json:"seriesId,omitempty"`
+	Rank     float64           `json:"rank"`
+	Titles   map[string]string `json:"titles"`
+	Image    *ImageRefDTO      `json:"image,omitempty"`
+}
+
+type SearchResultsDTO struct {
+	Query   string            `json:"query"`
+	Total   int               `json:"total"`
+	Limit   int               `json:"limit"`
+	Offset  int               `json:"offset"`
+	Results []SearchResultDTO `json:"results"`
+}
+
+func toSeriesSearchResult(s works.Series, rank float64) SearchResultDTO {
+	dto := SearchResultDTO{Kind: "series", ID: s.ID, Rank: rank, Titles: map[string]string{}}
+	if rev := pickSeriesRevisionDraftView(s); rev != nil {
+		for _, t := range rev.I18n {
+			dto.Titles[t.Lang] = t.Title
+		}
+		dto.Image = toImageRefDTO(rev.Image)
+	}
+	return dto
+}
+
+func toArtworkSearchResult(a works.Artwork, rank float64) SearchResultDTO {
+	dto := SearchResultDTO{Kind: "artwork", ID: a.ID, SeriesID: a.SeriesID, Rank: rank, Titles: map[string]string{}}
+	if rev := pickArtworkRevisionDraftView(a); rev != nil {
+		for _, t := range rev.I18n {
+			dto.Titles[t.Lang] = t.Title
+		}
+		dto.Image = toImageRefDTO(rev.Image)
+	}
+	return dto
 }
@@ -0,0 +1,86 @@
+package works
+
+import (
+	"sort"
+
+	"registration-app/internal/domain/media"
+	"registration-app/internal/domain/works"
+)
+
+// Revisions are flattened to "field path -> display value" maps, so a diff is
+// a plain comparison of two maps. Add new revision fields here to include them in diffs.
+
+func artworkRevisionFields(rev *works.ArtworkRevision) map[string]string {
+	f := map[string]string{}
+	if rev == nil {
+		return f
+	}
+
+	f["year"] = rev.Year
+	f["medium"] = rev.Medium
+	f["size_cm"] = rev.SizeCM
+	f["price"] = rev.Price
+	f["year_range"] = rev.YearDisplay()
+	f["medium_key"] = rev.MediumKey
+	f["dimensions"] = rev.SizeDisplay("")
+	f["price_details"] = rev.PriceVisibility + " " + rev.PriceDisplay("en")
+	f["image"] = imageDiffValue(rev.ImageID, rev.Image)
+
+	for _, t := range rev.I18n {
+		p := "i18n." + t.Lang + "."
+		f[p+"title"] = t.Title
+		f[p+"description"] = t.Description
+		f[p+"notes"] = t.Notes
+	}
+	artworkRecordFields(f, rev)
+	return f
+}
+
+func seriesRevisionFields(rev *works.SeriesRevision) map[string]string {
+	f := map[string]string{}
+	if rev == nil {
+		return f
+	}
+
+	f["image"] = imageDiffValue(rev.ImageID, rev.Image)
+
+	for _, t := range rev.I18n {
+		p := "i18n." + t.Lang + "."
+		f[p+"title"] = t.Title
+		f[p+"descriptionSerie"] = t.DescriptionSerie
+		f[p+"year"] = t.Year
+	}
+	return f
+}
+
+// image changes are reported by path; a re-upload of the same file is a change, a shared row is not
+func imageDiffValue(imageID *string, img *media.Image) string {
+	if img != nil {
+		return img.OriginalPath
+	}
+	if imageID != nil {
+		return *imageID
+	}
+	return ""
+}
+
+func diffFields(from, to map[string]string) []FieldChangeDTO {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make([]FieldChangeDTO, 0)
+	for _, k := range keys {
+		if from[k] != to[k] {
+			changes = append(changes, FieldChangeDTO{Field: k, From: from[k], To: to[k]})
+		}
+	}
+	return changes
+}
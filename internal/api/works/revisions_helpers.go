@@ -1,43 +1,77 @@
 package works
 
 import (
+	"fmt"
+
 	"registration-app/internal/domain/media"
 	"registration-app/internal/domain/works"
 
 	"gorm.io/gorm"
 )
 
+// A revision that has ever been published is history and never edited in place;
+// editing it (e.g. after unpublish moved it back to the draft pointer) clones it first.
+
 func ensureDraftSeriesRevision(tx *gorm.DB, s *works.Series) (*works.SeriesRevision, error) {
-	// draft already exists
+	// 1) editable draft already exists
 	if s.DraftRevisionID != nil && *s.DraftRevisionID != "" {
 		var dr works.SeriesRevision
 		if err := tx.Preload("I18n").First(&dr, "id = ?", *s.DraftRevisionID).Error; err != nil {
 			return nil, err
 		}
-		return &dr, nil
+		if dr.PublishedAt == nil {
+			return &dr, nil
+		}
+		return newSeriesDraftFrom(tx, s, &dr)
 	}
 
-	// clone from published if available
-	var base *works.SeriesRevision
+	// 2) clone from published if available
 	if s.PublishedRevisionID != nil && *s.PublishedRevisionID != "" {
 		var pr works.SeriesRevision
 		if err := tx.Preload("I18n").First(&pr, "id = ?", *s.PublishedRevisionID).Error; err != nil {
 			return nil, err
 		}
-		base = &pr
+		return newSeriesDraftFrom(tx, s, &pr)
+	}
+
+	// 3) nothing yet: empty draft
+	return newSeriesDraftFrom(tx, s, nil)
+}
+
+// newSeriesDraftFrom clones base (nil = empty) and points the series draft at the copy.
+func newSeriesDraftFrom(tx *gorm.DB, s *works.Series, base *works.SeriesRevision) (*works.SeriesRevision, error) {
+	dr, err := cloneSeriesRevision(tx, base, s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&works.Series{}).
+		Where("id = ?", s.ID).
+		Update("draft_revision_id", dr.ID).Error; err != nil {
+		return nil, err
 	}
+	s.DraftRevisionID = &dr.ID
 
-	dr := works.SeriesRevision{SeriesID: s.ID}
-	if base != nil {
-		dr.ImageID = base.ImageID
+	return dr, nil
+}
+
+// cloneSeriesRevision copies src (fields + i18n, sharing the image) into a new
+// unpublished revision of seriesID. src nil creates an empty revision.
+func cloneSeriesRevision(tx *gorm.DB, src *works.SeriesRevision, seriesID string) (*works.SeriesRevision, error) {
+	dr := works.SeriesRevision{SeriesID: seriesID}
+	if src != nil {
+		imageID, err := ownRevisionImageID(tx, src.ImageID)
+		if err != nil {
+			return nil, err
+		}
+		dr.ImageID = imageID
 	}
 	if err := tx.Create(&dr).Error; err != nil {
 		return nil, err
 	}
 
-	// copy i18n
-	if base != nil {
-		for _, t := range base.I18n {
+	if src != nil {
+		for _, t := range src.I18n {
 			row := works.SeriesI18nRevision{
 				SeriesRevisionID: dr.ID,
 				Lang:             t.Lang,
@@ -48,53 +82,108 @@ func ensureDraftSeriesRevision(tx *gorm.DB, s *works.Series) (*works.SeriesRevis
 			if err := tx.Create(&row).Error; err != nil {
 				return nil, err
 			}
+			dr.I18n = append(dr.I18n, row)
 		}
 	}
 
-	if err := tx.Model(&works.Series{}).
-		Where("id = ?", s.ID).
-		Update("draft_revision_id", dr.ID).Error; err != nil {
-		return nil, err
-	}
-
 	return &dr, nil
 }
 
 func ensureDraftArtworkRevision(tx *gorm.DB, a *works.Artwork) (*works.ArtworkRevision, error) {
-	// 1) if we already have a real draft, use it
+	// 1) if we already have a real draft, use it (unless it was published before)
 	if a.DraftRevisionID != nil && *a.DraftRevisionID != "" {
 		if a.PublishedRevisionID == nil || *a.PublishedRevisionID == "" || *a.DraftRevisionID != *a.PublishedRevisionID {
 			var dr works.ArtworkRevision
 			if err := tx.Preload("I18n").First(&dr, "id = ?", *a.DraftRevisionID).Error; err != nil {
 				return nil, err
 			}
-			return &dr, nil
+			if dr.PublishedAt == nil {
+				return &dr, nil
+			}
+			return newArtworkDraftFrom(tx, a, &dr)
 		}
 	}
 
 	// 2) otherwise clone published if exists
 	if a.PublishedRevisionID != nil && *a.PublishedRevisionID != "" {
-		// load published revision (+i18n)
 		var pr works.ArtworkRevision
 		if err := tx.Preload("I18n").First(&pr, "id = ?", *a.PublishedRevisionID).Error; err != nil {
 			return nil, err
 		}
+		return newArtworkDraftFrom(tx, a, &pr)
+	}
+
+	// 3) no published: create empty draft
+	return newArtworkDraftFrom(tx, a, nil)
+}
+
+// newArtworkDraftFrom clones base (nil = empty) and points the artwork draft at the copy.
+func newArtworkDraftFrom(tx *gorm.DB, a *works.Artwork, base *works.ArtworkRevision) (*works.ArtworkRevision, error) {
+	dr, err := cloneArtworkRevision(tx, base, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// IMPORTANT: point artwork to this new draft
+	if err := tx.Model(&works.Artwork{}).
+		Where("id = ?", a.ID).
+		Update("draft_revision_id", dr.ID).Error; err != nil {
+		return nil, err
+	}
+	a.DraftRevisionID = &dr.ID
 
-		// create new draft revision copying fields
-		dr := works.ArtworkRevision{
-			ArtworkID: a.ID,
-			Year:      pr.Year,
-			Medium:    pr.Medium,
-			SizeCM:    pr.SizeCM,
-			Price:     pr.Price,
-			ImageID:   pr.ImageID,
+	return dr, nil
+}
+
+// ownRevisionImageID is the image a cloned revision points at: uploads are shared,
+// legacy path-only rows are copied, because resolveImageInput edits those in place
+// and must not change the published revision or history through the draft.
+func ownRevisionImageID(tx *gorm.DB, imageID *string) (*string, error) {
+	if imageID == nil || *imageID == "" {
+		return imageID, nil
+	}
+	var img media.Image
+	if err := tx.First(&img, "id = ?", *imageID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
 		}
-		if err := tx.Create(&dr).Error; err != nil {
+		return nil, err
+	}
+	return ownImageID(tx, &img)
+}
+
+// cloneArtworkRevision copies src (fields, i18n and records, sharing uploaded images) into a new
+// unpublished revision of artworkID. src nil creates an empty revision.
+func cloneArtworkRevision(tx *gorm.DB, src *works.ArtworkRevision, artworkID string) (*works.ArtworkRevision, error) {
+	dr := works.ArtworkRevision{ArtworkID: artworkID}
+	if src != nil {
+		dr.Year = src.Year
+		dr.Medium = src.Medium
+		dr.SizeCM = src.SizeCM
+		dr.Price = src.Price
+		dr.YearFrom = src.YearFrom
+		dr.YearTo = src.YearTo
+		dr.MediumKey = src.MediumKey
+		dr.Height = src.Height
+		dr.Width = src.Width
+		dr.Depth = src.Depth
+		dr.DimensionUnit = src.DimensionUnit
+		dr.PriceMinor = src.PriceMinor
+		dr.PriceCurrency = src.PriceCurrency
+		dr.PriceVisibility = src.PriceVisibility
+
+		imageID, err := ownRevisionImageID(tx, src.ImageID)
+		if err != nil {
 			return nil, err
 		}
+		dr.ImageID = imageID
+	}
+	if err := tx.Create(&dr).Error; err != nil {
+		return nil, err
+	}
 
-		// clone i18n rows
-		for _, t := range pr.I18n {
+	if src != nil {
+		for _, t := range src.I18n {
 			row := works.ArtworkI18nRevision{
 				ArtworkRevisionID: dr.ID,
 				Lang:              t.Lang,
@@ -105,30 +194,13 @@ func ensureDraftArtworkRevision(tx *gorm.DB, a *works.Artwork) (*works.ArtworkRe
 			if err := tx.Create(&row).Error; err != nil {
 				return nil, err
 			}
+			dr.I18n = append(dr.I18n, row)
 		}
-
-		// IMPORTANT: point artwork to this new draft
-		if err := tx.Model(&works.Artwork{}).
-			Where("id = ?", a.ID).
-			Update("draft_revision_id", dr.ID).Error; err != nil {
+		if err := copyArtworkRecords(tx, src.ID, dr.ID); err != nil {
 			return nil, err
 		}
-
-		// update the struct in memory too (optional but avoids confusion)
-		a.DraftRevisionID = &dr.ID
-
-		return &dr, nil
 	}
 
-	// 3) no published: create empty draft
-	dr := works.ArtworkRevision{ArtworkID: a.ID}
-	if err := tx.Create(&dr).Error; err != nil {
-		return nil, err
-	}
-	if err := tx.Model(&works.Artwork{}).Where("id = ?", a.ID).Update("draft_revision_id", dr.ID).Error; err != nil {
-		return nil, err
-	}
-	a.DraftRevisionID = &dr.ID
 	return &dr, nil
 }
 
@@ -157,3 +229,44 @@ func upsertImage(tx *gorm.DB, currentImageID *string, inOriginal string, inWebp
 	}
 	return &img.ID, nil
 }
+
+// resolveImageInput returns the image ID a revision should point at.
+// Uploaded images are referenced by ID and must belong to userID (nil = any, for templates);
+// legacy inputs upsert their paths into currentImageID.
+func resolveImageInput(tx *gorm.DB, userID *uint, currentImageID *string, in *ImageInput) (*string, error) {
+	if in.ID != nil && *in.ID != "" {
+		q := tx.Model(&media.Image{}).Where("id = ?", *in.ID)
+		if userID != nil {
+			q = q.Where("user_id = ?", *userID)
+		}
+		var count int64
+		if err := q.Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("image not found")
+		}
+		return in.ID, nil
+	}
+
+	if in.OriginalPath == "" {
+		return nil, fmt.Errorf("image id or original_path required")
+	}
+
+	// never rewrite the paths of an uploaded image, it owns its file
+	if currentImageID != nil && *currentImageID != "" {
+		var current media.Image
+		if err := tx.Select("id", "storage_key").First(&current, "id = ?", *currentImageID).Error; err != nil && err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+		if current.StorageKey != nil {
+			currentImageID = nil
+		}
+	}
+
+	return upsertImage(tx, currentImageID, in.OriginalPath, in.WebpPath, in.AvifPath)
+}
+
+func isImageInputError(err error) bool {
+	return err.Error() == "image not found" || err.Error() == "image id or original_path required"
+}
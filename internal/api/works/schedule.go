@@ -0,0 +1,251 @@
+package works
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// bindOptionalJSON binds the request body into dst; an empty body is not an error.
+func bindOptionalJSON(c *gin.Context, dst interface{}) error {
+	if err := c.ShouldBindJSON(dst); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+func (r SchedulePublishRequest) Validate(now time.Time) error {
+	if r.UnpublishAt == nil {
+		return nil
+	}
+	start := now
+	if r.PublishAt != nil && r.PublishAt.After(now) {
+		start = *r.PublishAt
+	}
+	if !r.UnpublishAt.After(start) {
+		return fmt.Errorf("unpublish_at must be after publish_at and in the future")
+	}
+	return nil
+}
+
+// IsScheduled reports whether publishing is deferred to PublishAt.
+func (r SchedulePublishRequest) IsScheduled(now time.Time) bool {
+	return r.PublishAt != nil && r.PublishAt.After(now)
+}
+
+// scheduleUpdates are the column updates stored next to the publish pointers.
+// An immediate publish clears a pending publish_at; unpublish_at is only
+// replaced when given (cancel via DELETE .../schedule).
+func (r SchedulePublishRequest) scheduleUpdates(now time.Time) map[string]interface{} {
+	updates := map[string]interface{}{"publish_at": nil}
+	if r.IsScheduled(now) {
+		updates["publish_at"] = r.PublishAt.UTC()
+	}
+	if r.UnpublishAt != nil {
+		updates["unpublish_at"] = r.UnpublishAt.UTC()
+	}
+	return updates
+}
+
+func (r SchedulePublishRequest) response(now time.Time) gin.H {
+	out := gin.H{"status": "published"}
+	if r.IsScheduled(now) {
+		out["status"] = "scheduled"
+		out["publish_at"] = r.PublishAt.UTC()
+	}
+	if r.UnpublishAt != nil {
+		out["unpublish_at"] = r.UnpublishAt.UTC()
+	}
+	return out
+}
+
+// ------------------------------
+// DELETE /series/:id/schedule
+// Cancels pending publish_at / unpublish_at; the current state is kept.
+// ------------------------------
+func CancelSeriesSchedule(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	res := database.DB.Model(&works.Series{}).
+		Where("id = ? AND owner_type = ? AND user_id = ?", c.Param("id"), works.OwnerUser, userID).
+		Updates(map[string]interface{}{"publish_at": nil, "unpublish_at": nil})
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel schedule", "details": res.Error.Error()})
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "unscheduled"})
+}
+
+// ------------------------------
+// DELETE /artworks/:id/schedule
+// ------------------------------
+func CancelArtworkSchedule(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	res := database.DB.Model(&works.Artwork{}).
+		Where("id = ? AND owner_type = ? AND user_id = ?", c.Param("id"), works.OwnerUser, userID).
+		Updates(map[string]interface{}{"publish_at": nil, "unpublish_at": nil})
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel schedule", "details": res.Error.Error()})
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Artwork not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "unscheduled"})
+}
+
+// ---------- scheduler
+
+// ApplyDueSchedules publishes/unpublishes every series and artwork whose
+// schedule is due. Each row is claimed with FOR UPDATE SKIP LOCKED and its
+// schedule cleared in the same transaction, so a schedule is applied exactly
+// once even with several API replicas running the job. A row that fails is
+// logged and its due schedule dropped, so it cannot block the rows after it.
+func ApplyDueSchedules(ctx context.Context, db *gorm.DB) (int, error) {
+	applied := 0
+	for _, kind := range []struct {
+		name  string
+		model interface{}
+		next  func(tx *gorm.DB, now time.Time) (string, error)
+	}{
+		{"series", &works.Series{}, applyNextSeriesSchedule},
+		{"artwork", &works.Artwork{}, applyNextArtworkSchedule},
+	} {
+		for {
+			if err := ctx.Err(); err != nil {
+				return applied, err
+			}
+			now := time.Now()
+			var id string
+			err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				var err error
+				id, err = kind.next(tx, now)
+				return err
+			})
+			if id == "" {
+				if err != nil {
+					return applied, err
+				}
+				break // nothing due
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return applied, ctx.Err() // shutting down, not the row's fault
+				}
+				log.Printf("schedule %s %s failed, dropping it: %v", kind.name, id, err)
+				if derr := dropDueSchedule(db.WithContext(ctx), kind.model, id, now); derr != nil {
+					return applied, derr
+				}
+				continue
+			}
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+// dropDueSchedule clears the parts of a row's schedule that are due at now.
+func dropDueSchedule(db *gorm.DB, model interface{}, id string, now time.Time) error {
+	return db.Model(model).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"publish_at":   gorm.Expr("CASE WHEN publish_at <= ? THEN NULL ELSE publish_at END", now),
+			"unpublish_at": gorm.Expr("CASE WHEN unpublish_at <= ? THEN NULL ELSE unpublish_at END", now),
+		}).Error
+}
+
+const dueScheduleSQL = "((publish_at IS NOT NULL AND publish_at <= ?) OR (unpublish_at IS NOT NULL AND unpublish_at <= ?))"
+
+func applyNextSeriesSchedule(tx *gorm.DB, now time.Time) (string, error) {
+	var s works.Series
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("owner_type = ?", works.OwnerUser).
+		Where(dueScheduleSQL, now, now).
+		Order("id").
+		Limit(1).
+		Find(&s)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return "", res.Error
+	}
+
+	// locked items are never changed automatically; drop the schedule
+	if s.IDLocked {
+		return s.ID, tx.Model(&works.Series{}).
+			Where("id = ?", s.ID).
+			Updates(map[string]interface{}{"publish_at": nil, "unpublish_at": nil}).Error
+	}
+
+	if s.PublishAt != nil && !s.PublishAt.After(now) {
+		if _, err := publishSeriesDraft(tx, &s); err != nil {
+			return s.ID, err
+		}
+		if err := tx.Model(&works.Series{}).Where("id = ?", s.ID).Update("publish_at", nil).Error; err != nil {
+			return s.ID, err
+		}
+	}
+	if s.UnpublishAt != nil && !s.UnpublishAt.After(now) {
+		if err := unpublishSeries(tx, &s); err != nil {
+			return s.ID, err
+		}
+	}
+	return s.ID, nil
+}
+
+func applyNextArtworkSchedule(tx *gorm.DB, now time.Time) (string, error) {
+	var a works.Artwork
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("owner_type = ?", works.OwnerUser).
+		Where(dueScheduleSQL, now, now).
+		Order("id").
+		Limit(1).
+		Find(&a)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return "", res.Error
+	}
+
+	if a.IDLocked {
+		return a.ID, tx.Model(&works.Artwork{}).
+			Where("id = ?", a.ID).
+			Updates(map[string]interface{}{"publish_at": nil, "unpublish_at": nil}).Error
+	}
+
+	if a.PublishAt != nil && !a.PublishAt.After(now) {
+		if _, err := publishArtworkDraft(tx, &a); err != nil {
+			return a.ID, err
+		}
+		if err := tx.Model(&works.Artwork{}).Where("id = ?", a.ID).Update("publish_at", nil).Error; err != nil {
+			return a.ID, err
+		}
+	}
+	if a.UnpublishAt != nil && !a.UnpublishAt.After(now) {
+		if err := unpublishArtwork(tx, &a); err != nil {
+			return a.ID, err
+		}
+	}
+	return a.ID, nil
+}
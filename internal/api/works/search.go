@@ -0,0 +1,205 @@
+package works
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"registration-app/database"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Search
+	------
+	GET /works/search?q=<websearch syntax>&limit=20&offset=0
+
+	- artworks: i18n title/description/notes (stemmed per Lang) + medium/year
+	- series:   i18n title/description/year
+	- draft and published revisions both count; an item ranks by its best match
+	- trashed items are excluded
+*/
+
+const (
+	searchDefaultLimit = 20
+	searchMaxLimit     = 100
+)
+
+type searchHit struct {
+	Kind  string
+	ID    string
+	Rank  float64
+	Total int
+}
+
+// i18nMatchSQL matches vector (over a table with a lang column) against q,
+// one branch per configuration so each can use the expression index.
+func i18nMatchSQL(vector string) string {
+	var conds []string
+	for _, cfg := range works.TextSearchConfigNames() {
+		conds = append(conds, "(works_ts_config(lang) = '"+cfg+"'::regconfig AND "+vector+" @@ websearch_to_tsquery('"+cfg+"', @q))")
+	}
+	return "(" + strings.Join(conds, " OR ") + ")"
+}
+
+func searchSQL() string {
+	artworkMatch := i18nMatchSQL(works.ArtworkI18nSearchVector)
+	seriesMatch := i18nMatchSQL(works.SeriesI18nSearchVector)
+
+	return `
+WITH artwork_hits AS (
+	SELECT artwork_revision_id AS rev_id,
+		ts_rank(` + works.ArtworkI18nSearchVector + `, websearch_to_tsquery(works_ts_config(lang), @q)) AS rank
+	FROM artwork_i18n_revisions
+	WHERE ` + artworkMatch + `
+	UNION ALL
+	SELECT id AS rev_id,
+		ts_rank(` + works.ArtworkFieldsSearchVector + `, websearch_to_tsquery('simple', @q)) AS rank
+	FROM artwork_revisions
+	WHERE ` + works.ArtworkFieldsSearchVector + ` @@ websearch_to_tsquery('simple', @q)
+),
+series_hits AS (
+	SELECT series_revision_id AS rev_id,
+		ts_rank(` + works.SeriesI18nSearchVector + `, websearch_to_tsquery(works_ts_config(lang), @q)) AS rank
+	FROM series_i18n_revisions
+	WHERE ` + seriesMatch + `
+),
+hits AS (
+	SELECT 'artwork' AS kind, a.id, MAX(h.rank) AS rank
+	FROM artworks a
+	JOIN artwork_hits h ON h.rev_id IN (a.draft_revision_id, a.published_revision_id)
+	WHERE a.owner_type = @owner AND a.user_id = @user AND a.deleted_at IS NULL
+	GROUP BY a.id
+	UNION ALL
+	SELECT 'series' AS kind, s.id, MAX(h.rank) AS rank
+	FROM series s
+	JOIN series_hits h ON h.rev_id IN (s.draft_revision_id, s.published_revision_id)
+	WHERE s.owner_type = @owner AND s.user_id = @user AND s.deleted_at IS NULL
+	GROUP BY s.id
+)
+SELECT kind, id, rank, COUNT(*) OVER () AS total
+FROM hits
+ORDER BY rank DESC, kind DESC, id
+LIMIT @limit OFFSET @offset`
+}
+
+// ------------------------------
+// GET /works/search?q=
+// ------------------------------
+func SearchWorks(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q required"})
+		return
+	}
+
+	limit := searchDefaultLimit
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+	offset := 0
+	if v, err := strconv.Atoi(c.Query("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	var hits []searchHit
+	if err := database.DB.Raw(searchSQL(), map[string]interface{}{
+		"q":      q,
+		"owner":  works.OwnerUser,
+		"user":   userID,
+		"limit":  limit,
+		"offset": offset,
+	}).Scan(&hits).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Search failed", "details": err.Error()})
+		return
+	}
+
+	results, err := loadSearchResults(database.DB, hits)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load results", "details": err.Error()})
+		return
+	}
+
+	total := 0
+	if len(hits) > 0 {
+		total = hits[0].Total
+	}
+	c.JSON(http.StatusOK, SearchResultsDTO{
+		Query:   q,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		Results: results,
+	})
+}
+
+// loadSearchResults loads the hit rows (draft view) in rank order.
+func loadSearchResults(db *gorm.DB, hits []searchHit) ([]SearchResultDTO, error) {
+	var artworkIDs, seriesIDs []string
+	for _, h := range hits {
+		if h.Kind == "artwork" {
+			artworkIDs = append(artworkIDs, h.ID)
+		} else {
+			seriesIDs = append(seriesIDs, h.ID)
+		}
+	}
+
+	artworksByID := map[string]works.Artwork{}
+	if len(artworkIDs) > 0 {
+		var list []works.Artwork
+		if err := db.
+			Preload("DraftRevision.Image.Variants").
+			Preload("DraftRevision.I18n").
+			Preload("PublishedRevision.Image.Variants").
+			Preload("PublishedRevision.I18n").
+			Where("id IN ?", artworkIDs).
+			Find(&list).Error; err != nil {
+			return nil, err
+		}
+		for _, a := range list {
+			artworksByID[a.ID] = a
+		}
+	}
+
+	seriesByID := map[string]works.Series{}
+	if len(seriesIDs) > 0 {
+		var list []works.Series
+		if err := db.
+			Preload("DraftRevision.Image.Variants").
+			Preload("DraftRevision.I18n").
+			Preload("PublishedRevision.Image.Variants").
+			Preload("PublishedRevision.I18n").
+			Where("id IN ?", seriesIDs).
+			Find(&list).Error; err != nil {
+			return nil, err
+		}
+		for _, s := range list {
+			seriesByID[s.ID] = s
+		}
+	}
+
+	out := make([]SearchResultDTO, 0, len(hits))
+	for _, h := range hits {
+		if h.Kind == "artwork" {
+			if a, ok := artworksByID[h.ID]; ok {
+				out = append(out, toArtworkSearchResult(a, h.Rank))
+			}
+			continue
+		}
+		if s, ok := seriesByID[h.ID]; ok {
+			out = append(out, toSeriesSearchResult(s, h.Rank))
+		}
+	}
+	return out, nil
+}
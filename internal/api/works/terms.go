@@ -0,0 +1,331 @@
+package works
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"registration-app/database"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Tags & categories
+	-----------------
+	- terms are per user: GET/POST /terms, PUT/DELETE /terms/:id
+	- attached via term_ids on create/update of artworks and series (full list)
+	- GET /works?tag=..&category=.. filters by slug; series terms count for their items
+*/
+
+func userTermsQuery(db *gorm.DB, userID uint) *gorm.DB {
+	return db.Model(&works.Term{}).Where("user_id = ?", userID)
+}
+
+func orderTerms(db *gorm.DB) *gorm.DB {
+	return db.Order("kind ASC, sort_index ASC, slug ASC")
+}
+
+// ------------------------------
+// GET /terms?kind=tag|category
+// ------------------------------
+func ListTerms(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	q := userTermsQuery(database.DB, userID).Preload("I18n")
+	if kind := c.Query("kind"); kind != "" {
+		q = q.Where("kind = ?", kind)
+	}
+
+	var terms []works.Term
+	if err := orderTerms(q).Find(&terms).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load terms"})
+		return
+	}
+
+	out := make([]TermDTO, 0, len(terms))
+	for _, t := range terms {
+		out = append(out, toTermDTO(t))
+	}
+	c.JSON(http.StatusOK, gin.H{"terms": out})
+}
+
+// ------------------------------
+// POST /terms
+// ------------------------------
+func CreateTerm(c *gin.Context) {
+	var req CreateTermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !works.ValidTermKind(req.Kind) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be tag or category"})
+		return
+	}
+
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	slug := works.Slugify(req.Slug)
+	if slug == "" {
+		slug = works.Slugify(termDefaultLabel(req.I18n))
+	}
+	if slug == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slug or label required"})
+		return
+	}
+
+	t := works.Term{UserID: userID, Kind: req.Kind, Slug: slug}
+	if req.SortIndex != nil {
+		t.SortIndex = *req.SortIndex
+	}
+	for lang, v := range req.I18n {
+		t.I18n = append(t.I18n, works.TermI18n{Lang: lang, Label: v.Label})
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkTermSlugFree(tx, userID, t.Kind, t.Slug, ""); err != nil {
+			return err
+		}
+		return tx.Create(&t).Error
+	})
+	if err != nil {
+		if err.Error() == "slug taken" {
+			c.JSON(http.StatusConflict, gin.H{"error": "A " + req.Kind + " with this slug already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create term", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTermDTO(t))
+}
+
+// ------------------------------
+// PUT /terms/:id
+// ------------------------------
+func UpdateTerm(c *gin.Context) {
+	id := c.Param("id")
+
+	var req UpdateTermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var t works.Term
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := userTermsQuery(tx, userID).First(&t, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if req.Slug != nil {
+			slug := works.Slugify(*req.Slug)
+			if slug == "" {
+				return fmt.Errorf("invalid slug")
+			}
+			if err := checkTermSlugFree(tx, userID, t.Kind, slug, t.ID); err != nil {
+				return err
+			}
+			updates["slug"] = slug
+		}
+		if req.SortIndex != nil {
+			updates["sort_index"] = *req.SortIndex
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&works.Term{}).Where("id = ?", t.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		// upsert labels
+		for lang, v := range req.I18n {
+			row := works.TermI18n{TermID: t.ID, Lang: lang, Label: v.Label}
+			if err := tx.Save(&row).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Preload("I18n").First(&t, "id = ?", t.ID).Error
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+			return
+		}
+		if err.Error() == "slug taken" {
+			c.JSON(http.StatusConflict, gin.H{"error": "A " + t.Kind + " with this slug already exists"})
+			return
+		}
+		if err.Error() == "invalid slug" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update term", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toTermDTO(t))
+}
+
+// ------------------------------
+// DELETE /terms/:id (detaches it everywhere)
+// ------------------------------
+func DeleteTerm(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var t works.Term
+		if err := userTermsQuery(tx, userID).First(&t, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("term_id = ?", t.ID).Delete(&works.ArtworkTerm{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("term_id = ?", t.ID).Delete(&works.SeriesTerm{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("term_id = ?", t.ID).Delete(&works.TermI18n{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&works.Term{}, "id = ?", t.ID).Error
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete term", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// ---------- helpers
+
+func checkTermSlugFree(tx *gorm.DB, userID uint, kind, slug, exceptID string) error {
+	q := userTermsQuery(tx, userID).Where("kind = ? AND slug = ?", kind, slug)
+	if exceptID != "" {
+		q = q.Where("id <> ?", exceptID)
+	}
+	var count int64
+	if err := q.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("slug taken")
+	}
+	return nil
+}
+
+// termDefaultLabel picks the English label, else any, for slug generation.
+func termDefaultLabel(i18n map[string]TermI18nInput) string {
+	if v, ok := i18n["en"]; ok && strings.TrimSpace(v.Label) != "" {
+		return v.Label
+	}
+	for _, v := range i18n {
+		if strings.TrimSpace(v.Label) != "" {
+			return v.Label
+		}
+	}
+	return ""
+}
+
+// userTermIDs checks that every id is a term of userID and returns them deduplicated.
+func userTermIDs(tx *gorm.DB, userID uint, ids []string) ([]string, error) {
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return unique, nil
+	}
+	var count int64
+	if err := userTermsQuery(tx, userID).Where("id IN ?", unique).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if int(count) != len(unique) {
+		return nil, fmt.Errorf("term not found")
+	}
+	return unique, nil
+}
+
+// setArtworkTerms replaces the terms of artworkID.
+func setArtworkTerms(tx *gorm.DB, userID uint, artworkID string, ids []string) error {
+	ids, err := userTermIDs(tx, userID, ids)
+	if err != nil {
+		return err
+	}
+	if err := tx.Where("artwork_id = ?", artworkID).Delete(&works.ArtworkTerm{}).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows := make([]works.ArtworkTerm, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, works.ArtworkTerm{ArtworkID: artworkID, TermID: id})
+	}
+	return tx.Create(&rows).Error
+}
+
+// setSeriesTerms replaces the terms of seriesID.
+func setSeriesTerms(tx *gorm.DB, userID uint, seriesID string, ids []string) error {
+	ids, err := userTermIDs(tx, userID, ids)
+	if err != nil {
+		return err
+	}
+	if err := tx.Where("series_id = ?", seriesID).Delete(&works.SeriesTerm{}).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows := make([]works.SeriesTerm, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, works.SeriesTerm{SeriesID: seriesID, TermID: id})
+	}
+	return tx.Create(&rows).Error
+}
+
+// copyArtworkTerms gives dstID the terms of srcID (duplicates).
+func copyArtworkTerms(tx *gorm.DB, srcID, dstID string) error {
+	var ids []string
+	if err := tx.Model(&works.ArtworkTerm{}).Where("artwork_id = ?", srcID).Pluck("term_id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows := make([]works.ArtworkTerm, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, works.ArtworkTerm{ArtworkID: dstID, TermID: id})
+	}
+	return tx.Create(&rows).Error
+}
@@ -0,0 +1,310 @@
+package works
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"registration-app/database"
+	"registration-app/internal/domain/works"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+/*
+	Trash
+	-----
+	- DELETE on series/artworks only sets deleted_at (gorm soft delete), so every
+	  gorm query (queries.go, preloads) skips trashed rows automatically
+	- trashing a series trashes its live artworks with the same deleted_at;
+	  restoring the series brings exactly those back
+	- PurgeTrash hard-deletes rows older than TRASH_RETENTION_DAYS (+ revisions)
+*/
+
+// TrashRetention returns TRASH_RETENTION_DAYS (default 30 days).
+func TrashRetention() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && v >= 0 {
+		return time.Duration(v) * 24 * time.Hour
+	}
+	return 30 * 24 * time.Hour
+}
+
+// trashSeries soft-deletes s and its artworks with one timestamp.
+func trashSeries(tx *gorm.DB, s works.Series, now time.Time) error {
+	if err := tx.Model(&works.Artwork{}).
+		Where("series_id = ?", s.ID).
+		Update("deleted_at", now).Error; err != nil {
+		return err
+	}
+	return tx.Model(&works.Series{}).
+		Where("id = ?", s.ID).
+		Update("deleted_at", now).Error
+}
+
+// ------------------------------
+// GET /trash
+// ------------------------------
+func ListTrash(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	retention := TrashRetention()
+
+	var series []works.Series
+	if err := database.DB.Unscoped().
+		Preload("DraftRevision.I18n").
+		Preload("PublishedRevision.I18n").
+		Where("owner_type = ? AND user_id = ? AND deleted_at IS NOT NULL", works.OwnerUser, userID).
+		Order("deleted_at DESC").
+		Find(&series).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load trash"})
+		return
+	}
+
+	// artworks trashed together with their series are restored with it, list them there
+	var artworks []works.Artwork
+	if err := database.DB.Unscoped().
+		Preload("DraftRevision.I18n").
+		Preload("PublishedRevision.I18n").
+		Where("owner_type = ? AND user_id = ? AND deleted_at IS NOT NULL", works.OwnerUser, userID).
+		Where("NOT EXISTS (SELECT 1 FROM series s WHERE s.id = artworks.series_id AND s.deleted_at = artworks.deleted_at)").
+		Order("deleted_at DESC").
+		Find(&artworks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load trash"})
+		return
+	}
+
+	type countRow struct {
+		SeriesID string
+		N        int
+	}
+	var counts []countRow
+	if len(series) > 0 {
+		ids := make([]string, 0, len(series))
+		for _, s := range series {
+			ids = append(ids, s.ID)
+		}
+		if err := database.DB.Unscoped().Model(&works.Artwork{}).
+			Select("artworks.series_id, COUNT(*) AS n").
+			Joins("JOIN series s ON s.id = artworks.series_id AND s.deleted_at = artworks.deleted_at").
+			Where("artworks.series_id IN ?", ids).
+			Group("artworks.series_id").
+			Scan(&counts).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load trash"})
+			return
+		}
+	}
+	countBySeries := map[string]int{}
+	for _, r := range counts {
+		countBySeries[r.SeriesID] = r.N
+	}
+
+	out := TrashListDTO{Items: make([]TrashItemDTO, 0, len(series)+len(artworks))}
+	for _, s := range series {
+		out.Items = append(out.Items, toSeriesTrashItem(s, countBySeries[s.ID], retention))
+	}
+	for _, a := range artworks {
+		out.Items = append(out.Items, toArtworkTrashItem(a, retention))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ------------------------------
+// POST /trash/:id/restore  (series or artwork id)
+// ------------------------------
+func RestoreFromTrash(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+
+	var kind string
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var s works.Series
+		res := tx.Unscoped().
+			Where("id = ? AND owner_type = ? AND user_id = ? AND deleted_at IS NOT NULL", id, works.OwnerUser, userID).
+			Limit(1).
+			Find(&s)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			kind = "series"
+			if err := tx.Unscoped().Model(&works.Artwork{}).
+				Where("series_id = ? AND deleted_at = ?", s.ID, s.DeletedAt.Time).
+				Update("deleted_at", nil).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Model(&works.Series{}).
+				Where("id = ?", s.ID).
+				Update("deleted_at", nil).Error
+		}
+
+		var a works.Artwork
+		if err := tx.Unscoped().
+			First(&a, "id = ? AND owner_type = ? AND user_id = ? AND deleted_at IS NOT NULL", id, works.OwnerUser, userID).Error; err != nil {
+			return err
+		}
+		kind = "artwork"
+
+		// the series must be live again first
+		var count int64
+		if err := tx.Model(&works.Series{}).Where("id = ?", a.SeriesID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("series in trash")
+		}
+
+		return tx.Unscoped().Model(&works.Artwork{}).
+			Where("id = ?", a.ID).
+			Update("deleted_at", nil).Error
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found in trash"})
+			return
+		}
+		if err.Error() == "series in trash" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Restore the series first"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "restored", "kind": kind, "id": id})
+}
+
+// ---------- purge
+
+type TrashPurgeReport struct {
+	Series   int `json:"series"`
+	Artworks int `json:"artworks"`
+}
+
+// PurgeTrash hard-deletes series and artworks trashed before now-retention,
+// including all their revisions. Each item is purged in its own transaction.
+func PurgeTrash(ctx context.Context, db *gorm.DB, retention time.Duration) (TrashPurgeReport, error) {
+	var report TrashPurgeReport
+	cutoff := time.Now().Add(-retention)
+
+	var seriesIDs []string
+	if err := db.WithContext(ctx).Unscoped().Model(&works.Series{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &seriesIDs).Error; err != nil {
+		return report, err
+	}
+	for _, id := range seriesIDs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		n, err := purgeSeries(db.WithContext(ctx), id)
+		if err != nil {
+			return report, err
+		}
+		report.Series++
+		report.Artworks += n
+	}
+
+	var artworkIDs []string
+	if err := db.WithContext(ctx).Unscoped().Model(&works.Artwork{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &artworkIDs).Error; err != nil {
+		return report, err
+	}
+	if len(artworkIDs) > 0 {
+		if err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return purgeArtworks(tx, artworkIDs)
+		}); err != nil {
+			return report, err
+		}
+		report.Artworks += len(artworkIDs)
+	}
+
+	return report, nil
+}
+
+// purgeSeries removes a series, all its artworks (live or trashed) and revisions.
+func purgeSeries(db *gorm.DB, seriesID string) (int, error) {
+	var purged int
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var artworkIDs []string
+		if err := tx.Unscoped().Model(&works.Artwork{}).
+			Where("series_id = ?", seriesID).
+			Pluck("id", &artworkIDs).Error; err != nil {
+			return err
+		}
+		if err := purgeArtworks(tx, artworkIDs); err != nil {
+			return err
+		}
+		purged = len(artworkIDs)
+
+		var revIDs []string
+		if err := tx.Model(&works.SeriesRevision{}).
+			Where("series_id = ?", seriesID).
+			Pluck("id", &revIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("series_id = ?", seriesID).Delete(&works.SeriesTerm{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("id = ?", seriesID).Delete(&works.Series{}).Error; err != nil {
+			return err
+		}
+		if len(revIDs) > 0 {
+			if err := tx.Where("series_revision_id IN ?", revIDs).Delete(&works.SeriesI18nRevision{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("id IN ?", revIDs).Delete(&works.SeriesRevision{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+// purgeArtworks hard-deletes artworks, their editions, terms and revisions (+i18n).
+// Identity rows go first, they reference the revisions.
+func purgeArtworks(tx *gorm.DB, artworkIDs []string) error {
+	if len(artworkIDs) == 0 {
+		return nil
+	}
+
+	var revIDs []string
+	if err := tx.Model(&works.ArtworkRevision{}).
+		Where("artwork_id IN ?", artworkIDs).
+		Pluck("id", &revIDs).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("artwork_id IN ?", artworkIDs).Delete(&works.ArtworkEdition{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("artwork_id IN ?", artworkIDs).Delete(&works.ArtworkTerm{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("id IN ?", artworkIDs).Delete(&works.Artwork{}).Error; err != nil {
+		return err
+	}
+	if len(revIDs) > 0 {
+		if err := tx.Where("artwork_revision_id IN ?", revIDs).Delete(&works.ArtworkI18nRevision{}).Error; err != nil {
+			return err
+		}
+		if err := deleteArtworkRecords(tx, revIDs); err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", revIDs).Delete(&works.ArtworkRevision{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
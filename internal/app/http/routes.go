@@ -1,15 +1,19 @@
 package routes
 
 import (
+	"strings"
+
 	adminapi "registration-app/internal/api/admin"
 	authapi "registration-app/internal/api/auth"
 	"registration-app/internal/api/billing"
+	mediaapi "registration-app/internal/api/media"
 	"registration-app/internal/api/plans"
 	siteapi "registration-app/internal/api/site"
 	stripewebhooks "registration-app/internal/api/stripewebhook"
 	"registration-app/internal/api/users"
 	worksapi "registration-app/internal/api/works"
 	"registration-app/internal/app/http/middleware"
+	"registration-app/internal/infra/storage"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,11 +21,20 @@ import (
 func RegisterRoutes(r *gin.Engine) {
 	// ✅ Apply input sanitization to public routes only
 
+	// artists' sites as HTML on their own hosts; API_HOST/APP_URL/CORS_ORIGIN
+	// hosts fall through without a lookup
+	r.Use(siteapi.PublicSiteHTML())
+
 	r.POST("/webhook", stripewebhooks.StripeWebhook)
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// local uploads are served by the API; S3 objects come from their own public URL
+	if ls, ok := storage.Store.(*storage.LocalStorage); ok && strings.HasPrefix(ls.BaseURL, "/") {
+		r.Static(ls.BaseURL, ls.Root)
+	}
+
 	r.GET("/templates/site", siteapi.ListSiteTemplates)
 	r.GET("/templates/site/:slug", siteapi.GetSiteTemplate)
 
@@ -39,6 +52,15 @@ func RegisterRoutes(r *gin.Engine) {
 	public.GET("/auth/google", authapi.GoogleStart)
 	public.GET("/auth/google/callback", authapi.GoogleCallback)
 
+	public.GET("/verify-certificate/:code", worksapi.VerifyCertificate)
+
+	public.GET("/public/site", siteapi.GetPublicSite)
+	public.GET("/public/site/pages/:page", siteapi.GetPublicSitePage)
+	public.GET("/public/site/works", siteapi.GetPublicSiteWorks)
+	public.GET("/public/sites/:site", siteapi.GetPublicSite)
+	public.GET("/public/sites/:site/pages/:page", siteapi.GetPublicSitePage)
+	public.GET("/public/sites/:site/works", siteapi.GetPublicSiteWorks)
+
 	// Authenticated
 	auth := r.Group("/")
 	auth.Use(middleware.AuthMiddleware())
@@ -49,18 +71,36 @@ func RegisterRoutes(r *gin.Engine) {
 	auth.POST("/change-password", authapi.ChangePassword)
 	auth.POST("/cancel-downgrade", billing.CancelDowngrade)
 
+	auth.POST("/media/images", mediaapi.UploadImage)
+	auth.GET("/media/images/:id", mediaapi.GetImage)
+	auth.POST("/media/images/:id/reprocess", mediaapi.ReprocessImage)
+
 	auth.GET("/works", worksapi.GetWorksJSON)
+	auth.GET("/works/search", worksapi.SearchWorks)
+	auth.GET("/works/export", worksapi.ExportWorks)
+	auth.POST("/works/import", worksapi.ImportWorks)
+	auth.POST("/works/pdf", worksapi.CataloguePDF)
+	auth.GET("/works/vocabulary/mediums", worksapi.GetMediumVocabulary)
+
+	auth.GET("/terms", worksapi.ListTerms)
+	auth.POST("/terms", worksapi.CreateTerm)
+	auth.PUT("/terms/:id", worksapi.UpdateTerm)
+	auth.DELETE("/terms/:id", worksapi.DeleteTerm)
+
 	auth.GET("/templates/works", worksapi.GetTemplateWorksJSON)
 
 	auth.GET("/series/:id", worksapi.GetSeriesByID)
 	auth.GET("/artworks/:id", worksapi.GetArtworkByID)
 
 	auth.POST("/series", worksapi.CreateSeries)
+	auth.PUT("/series/reorder", worksapi.ReorderSeries)
 	auth.PUT("/series/:id", worksapi.UpdateSeries)
 	auth.DELETE("/series/:id", worksapi.DeleteSeries)
 
 	auth.POST("/series/:id/publish", worksapi.PublishSeries)
 	auth.POST("/series/:id/unpublish", worksapi.UnpublishSeries)
+	auth.DELETE("/series/:id/schedule", worksapi.CancelSeriesSchedule)
+	auth.POST("/series/:id/publish-all", worksapi.PublishSeriesWithArtworks)
 
 	auth.POST("/series/:id/artworks", worksapi.CreateArtwork)
 	auth.DELETE("/series/:id/artworks", worksapi.DeleteAllArtworksOfSeries)
@@ -69,16 +109,47 @@ func RegisterRoutes(r *gin.Engine) {
 
 	auth.POST("/artworks/:id/publish", worksapi.PublishArtwork)
 	auth.POST("/artworks/:id/unpublish", worksapi.UnpublishArtwork)
+	auth.DELETE("/artworks/:id/schedule", worksapi.CancelArtworkSchedule)
+
+	auth.PUT("/artworks/:id/editions/:editionId", worksapi.UpdateArtworkEdition)
 
 	auth.PUT("/series/:id/artworks/reorder", worksapi.ReorderArtworks)
+	auth.POST("/artworks/:id/move", worksapi.MoveArtwork)
+	auth.POST("/artworks/:id/duplicate", worksapi.DuplicateArtwork)
+	auth.POST("/artworks/:id/certificate", worksapi.IssueArtworkCertificate)
+
+	auth.GET("/artworks/:id/revisions", worksapi.ListArtworkRevisions)
+	auth.GET("/artworks/:id/revisions/diff", worksapi.DiffArtworkRevisions)
+	auth.POST("/artworks/:id/revisions/:rev/restore", worksapi.RestoreArtworkRevision)
+	auth.GET("/series/:id/revisions", worksapi.ListSeriesRevisions)
+	auth.GET("/series/:id/revisions/diff", worksapi.DiffSeriesRevisions)
+	auth.POST("/series/:id/revisions/:rev/restore", worksapi.RestoreSeriesRevision)
 
 	auth.POST("/templates/series/:id/copy", worksapi.CopyTemplateSeriesToUser)
 
 	auth.GET("/site", siteapi.GetUserSite)
 	auth.POST("/site/from-template/:slug", siteapi.CopySiteFromTemplate)
+	auth.GET("/site/block-types", siteapi.ListBlockTypes)
+	auth.POST("/site/pages", siteapi.CreateSitePage)
+	auth.PATCH("/site/pages/:id", siteapi.UpdateSitePage)
+	auth.PUT("/site/pages/:id", siteapi.SaveSitePage)
+	auth.DELETE("/site/pages/:id", siteapi.DeleteSitePage)
+	auth.POST("/site/pages/:id/languages", siteapi.AddSitePageLanguage)
+	auth.POST("/site/pages/:id/publish", siteapi.PublishSitePage)
+	auth.POST("/site/pages/:id/unpublish", siteapi.UnpublishSitePage)
+	auth.POST("/site/pages/:id/discard", siteapi.DiscardSitePageDraft)
+	auth.GET("/site/pages/:id/revisions", siteapi.ListSitePageRevisions)
+	auth.POST("/site/pages/:id/revisions/:rev/restore", siteapi.RestoreSitePageRevision)
+	auth.POST("/site/pages/:id/blocks", siteapi.CreateSiteBlock)
+	auth.PUT("/site/pages/:id/blocks/reorder", siteapi.ReorderSiteBlocks)
+	auth.PUT("/site/pages/:id/blocks/:blockId", siteapi.UpdateSiteBlock)
+	auth.DELETE("/site/pages/:id/blocks/:blockId", siteapi.DeleteSiteBlock)
 
 	auth.POST("/series/:id/artworks/discard-drafts", worksapi.BulkDiscardArtworkDrafts)
 
+	auth.GET("/trash", worksapi.ListTrash)
+	auth.POST("/trash/:id/restore", worksapi.RestoreFromTrash)
+
 	// Subscribed users
 	subscribed := auth.Group("/")
 	subscribed.Use(middleware.RequireActiveSubscription())
@@ -93,6 +164,7 @@ func RegisterRoutes(r *gin.Engine) {
 	admin.GET("/user/:id", adminapi.GetUserDetails)
 	admin.POST("/sync-plans", plans.SyncPlansFromStripe)
 	admin.POST("/templates/series", worksapi.CreateTemplateSeries)
+	admin.PUT("/templates/series/reorder", worksapi.ReorderTemplateSeries)
 	admin.POST("/templates/series/:id/artworks", worksapi.CreateTemplateArtwork)
 
 }
@@ -0,0 +1,215 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"registration-app/internal/domain/media"
+	"registration-app/internal/infra/imaging"
+	"registration-app/internal/infra/storage"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// responsive widths; an image narrower than a width gets one variant at its own width instead
+var VariantWidths = []int{400, 800, 1600}
+
+const (
+	maxProcessingAttempts = 5
+	processingLease       = 10 * time.Minute // a crashed worker's claim expires after this
+)
+
+// ProcessPendingImages claims up to limit pending images and generates their
+// derivatives. Returns how many images were processed successfully.
+func ProcessPendingImages(ctx context.Context, db *gorm.DB, store storage.Storage, limit int) (int, error) {
+	claimed, err := claimPendingImages(db, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, img := range claimed {
+		if err := generateDerivatives(ctx, db, store, img); err != nil {
+			if ferr := markProcessingFailed(db, img, err); ferr != nil {
+				return done, ferr
+			}
+			continue
+		}
+		done++
+	}
+	return done, nil
+}
+
+func claimPendingImages(db *gorm.DB, limit int) ([]media.Image, error) {
+	var claimed []media.Image
+	now := time.Now()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("storage_key IS NOT NULL").
+			Where("((status = ? AND (process_after IS NULL OR process_after <= ?)) OR (status = ? AND process_after <= ?))",
+				media.StatusPending, now, media.StatusProcessing, now).
+			Order("created_at ASC").
+			Limit(limit).
+			Find(&claimed).Error; err != nil {
+			return err
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		ids := make([]string, 0, len(claimed))
+		for _, img := range claimed {
+			ids = append(ids, img.ID)
+		}
+		lease := now.Add(processingLease)
+		return tx.Model(&media.Image{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":              media.StatusProcessing,
+				"process_after":       lease,
+				"processing_attempts": gorm.Expr("processing_attempts + 1"),
+			}).Error
+	})
+
+	return claimed, err
+}
+
+// generateDerivatives is idempotent: object keys are deterministic and variant
+// rows are upserted on (image_id, format, width).
+func generateDerivatives(ctx context.Context, db *gorm.DB, store storage.Storage, img media.Image) error {
+	rc, err := store.Open(ctx, *img.StorageKey)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return err
+	}
+
+	src, _, err := imaging.Decode(data)
+	if err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	b := src.Bounds()
+
+	owner := "shared"
+	if img.UserID != nil {
+		owner = fmt.Sprintf("%d", *img.UserID)
+	}
+
+	widths := variantWidthsFor(b.Dx())
+	variants := make([]media.ImageVariant, 0, len(widths))
+	for _, w := range widths {
+		resized := imaging.ResizeToWidth(src, w)
+
+		var buf bytes.Buffer
+		if err := imaging.EncodeWebP(&buf, resized); err != nil {
+			return fmt.Errorf("encode webp %d: %w", w, err)
+		}
+
+		// lossless VP8L of a photo can outgrow the JPEG it came from: the original serves better
+		size := int64(buf.Len())
+		if size >= int64(len(data)) {
+			continue
+		}
+
+		key := fmt.Sprintf("users/%s/variants/%s/w%d.webp", owner, img.ID, w)
+		if err := store.Put(ctx, key, &buf, "image/webp"); err != nil {
+			return err
+		}
+
+		variants = append(variants, media.ImageVariant{
+			ImageID:    img.ID,
+			Format:     "webp",
+			Width:      resized.Bounds().Dx(),
+			Height:     resized.Bounds().Dy(),
+			Bytes:      size,
+			Path:       store.URL(key),
+			StorageKey: key,
+		})
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"width":            b.Dx(),
+		"height":           b.Dy(),
+		"status":           media.StatusReady,
+		"processing_error": "",
+		"process_after":    nil,
+		"processed_at":     now,
+	}
+	// webp_path is the full-size image for legacy consumers: only set it when
+	// the top width survived, never to a smaller variant
+	if n := len(variants); n > 0 && variants[n-1].Width == widths[len(widths)-1] {
+		updates["webp_path"] = variants[n-1].Path
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// drop variants from an older width set (or all of them)
+		stale := tx.Where("image_id = ? AND format = ?", img.ID, "webp")
+		if len(variants) > 0 {
+			kept := make([]int, 0, len(variants))
+			for _, v := range variants {
+				kept = append(kept, v.Width)
+			}
+			stale = stale.Where("width NOT IN ?", kept)
+
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "image_id"}, {Name: "format"}, {Name: "width"}},
+				DoUpdates: clause.AssignmentColumns([]string{"height", "bytes", "path", "storage_key", "updated_at"}),
+			}).Create(&variants).Error; err != nil {
+				return err
+			}
+		}
+		if err := stale.Delete(&media.ImageVariant{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&media.Image{}).
+			Where("id = ?", img.ID).
+			Updates(updates).Error
+	})
+}
+
+func markProcessingFailed(db *gorm.DB, img media.Image, cause error) error {
+	attempts := img.ProcessingAttempts + 1 // incremented when claimed
+
+	updates := map[string]interface{}{
+		"processing_error": cause.Error(),
+	}
+	if attempts >= maxProcessingAttempts {
+		updates["status"] = media.StatusFailed
+		updates["process_after"] = nil
+	} else {
+		// exponential backoff: 1m, 2m, 4m, ...
+		updates["status"] = media.StatusPending
+		updates["process_after"] = time.Now().Add(time.Minute * time.Duration(1<<(attempts-1)))
+	}
+
+	return db.Model(&media.Image{}).Where("id = ?", img.ID).Updates(updates).Error
+}
+
+func variantWidthsFor(originalWidth int) []int {
+	out := make([]int, 0, len(VariantWidths))
+	for _, w := range VariantWidths {
+		if w < originalWidth {
+			out = append(out, w)
+		}
+	}
+	// always include one variant at (capped) original width
+	top := originalWidth
+	if max := VariantWidths[len(VariantWidths)-1]; top > max {
+		top = max
+	}
+	if len(out) == 0 || out[len(out)-1] != top {
+		out = append(out, top)
+	}
+	return out
+}
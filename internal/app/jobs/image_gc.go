@@ -0,0 +1,193 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"registration-app/internal/domain/media"
+	"registration-app/internal/infra/storage"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// imageReferencedSQL is true while anything still points at the image.
+// Revisions only count while their artwork/series row exists (trashed rows included);
+// certificates keep their image for as long as they exist. Site blocks refer to
+// images through the imageId / imageIds props of their schemas (site.BlockTypes);
+// containment queries use idx_site_page_blocks_props.
+const imageReferencedSQL = `(
+	EXISTS (SELECT 1 FROM artwork_revisions ar JOIN artworks a ON a.id = ar.artwork_id WHERE ar.image_id = images.id)
+	OR EXISTS (SELECT 1 FROM series_revisions sr JOIN series s ON s.id = sr.series_id WHERE sr.image_id = images.id)
+	OR EXISTS (SELECT 1 FROM artworks a WHERE a.image_id = images.id)
+	OR EXISTS (SELECT 1 FROM artwork_certificates c WHERE c.image_id = images.id)
+	OR EXISTS (
+		SELECT 1 FROM site_page_blocks b
+		WHERE b.props @> jsonb_build_object('imageId', images.id::text)
+		   OR b.props @> jsonb_build_object('imageIds', jsonb_build_array(images.id::text))
+	)
+)`
+
+type ImageGCOptions struct {
+	GracePeriod time.Duration // how long an image must stay unreferenced before deletion
+	DryRun      bool          // report only, change nothing
+}
+
+type ImageGCItem struct {
+	ID           string     `json:"id"`
+	OriginalPath string     `json:"original_path"`
+	OrphanedAt   *time.Time `json:"orphaned_at,omitempty"`
+	Bytes        int64      `json:"bytes"`
+	Files        int        `json:"files"`
+	Action       string     `json:"action"` // "delete" | "wait"
+	Error        string     `json:"error,omitempty"`
+}
+
+type ImageGCReport struct {
+	DryRun     bool          `json:"dry_run"`
+	Orphaned   int           `json:"orphaned"`
+	NewlyFound int           `json:"newly_found"`
+	Deleted    int           `json:"deleted"`
+	FreedBytes int64         `json:"freed_bytes"`
+	Items      []ImageGCItem `json:"items"`
+}
+
+// SweepOrphanImages marks unreferenced images, and deletes rows + files of
+// images that stayed unreferenced for longer than the grace period.
+func SweepOrphanImages(ctx context.Context, db *gorm.DB, store storage.Storage, opts ImageGCOptions) (ImageGCReport, error) {
+	report := ImageGCReport{DryRun: opts.DryRun, Items: []ImageGCItem{}}
+	now := time.Now()
+	cutoff := now.Add(-opts.GracePeriod)
+
+	var orphans []media.Image
+	if err := db.Preload("Variants").
+		Where("NOT " + imageReferencedSQL).
+		Order("created_at ASC").
+		Find(&orphans).Error; err != nil {
+		return report, err
+	}
+
+	if !opts.DryRun {
+		// referenced again -> reset the clock
+		if err := db.Model(&media.Image{}).
+			Where("orphaned_at IS NOT NULL AND "+imageReferencedSQL).
+			Update("orphaned_at", nil).Error; err != nil {
+			return report, err
+		}
+	}
+
+	for _, img := range orphans {
+		item := ImageGCItem{
+			ID:           img.ID,
+			OriginalPath: img.OriginalPath,
+			OrphanedAt:   img.OrphanedAt,
+			Bytes:        img.Bytes,
+			Files:        len(img.Variants),
+			Action:       "wait",
+		}
+		if img.StorageKey != nil {
+			item.Files++
+		}
+		for _, v := range img.Variants {
+			item.Bytes += v.Bytes
+		}
+		report.Orphaned++
+
+		if img.OrphanedAt == nil {
+			report.NewlyFound++
+			if !opts.DryRun {
+				if err := db.Model(&media.Image{}).Where("id = ?", img.ID).Update("orphaned_at", now).Error; err != nil {
+					return report, err
+				}
+				item.OrphanedAt = &now
+			}
+			report.Items = append(report.Items, item)
+			continue
+		}
+
+		if img.OrphanedAt.After(cutoff) {
+			report.Items = append(report.Items, item)
+			continue
+		}
+
+		item.Action = "delete"
+		if opts.DryRun {
+			report.Items = append(report.Items, item)
+			continue
+		}
+
+		deleted, keys, err := deleteOrphanImage(db, img.ID)
+		if err != nil {
+			item.Error = err.Error()
+			report.Items = append(report.Items, item)
+			continue
+		}
+		if !deleted {
+			// referenced again or handled by another replica
+			item.Action = "wait"
+			report.Items = append(report.Items, item)
+			continue
+		}
+
+		// rows are gone; files go last so a failed commit never leaves broken images
+		for _, key := range keys {
+			if err := store.Delete(ctx, key); err != nil {
+				item.Error = err.Error()
+			}
+		}
+		report.Deleted++
+		report.FreedBytes += item.Bytes
+		report.Items = append(report.Items, item)
+	}
+
+	return report, nil
+}
+
+// deleteOrphanImage re-checks the image under a row lock and deletes it with
+// its variants. Returns the storage keys that are no longer used by any row.
+func deleteOrphanImage(db *gorm.DB, imageID string) (bool, []string, error) {
+	var keys []string
+	deleted := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var img media.Image
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Variants").
+			Where("id = ? AND orphaned_at IS NOT NULL AND NOT "+imageReferencedSQL, imageID).
+			Limit(1).
+			Find(&img)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		for _, v := range img.Variants {
+			keys = append(keys, v.StorageKey)
+		}
+		if img.StorageKey != nil {
+			// copied rows may share the original file
+			var shared int64
+			if err := tx.Model(&media.Image{}).
+				Where("storage_key = ? AND id <> ?", *img.StorageKey, img.ID).
+				Count(&shared).Error; err != nil {
+				return err
+			}
+			if shared == 0 {
+				keys = append(keys, *img.StorageKey)
+			}
+		}
+
+		if err := tx.Where("image_id = ?", img.ID).Delete(&media.ImageVariant{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&media.Image{}, "id = ?", img.ID).Error; err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+
+	return deleted, keys, err
+}
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"registration-app/database"
+	worksapi "registration-app/internal/api/works"
+	"registration-app/internal/infra/storage"
+)
+
+/*
+	Background jobs
+	---------------
+	- Run in-process next to the API (every replica runs them)
+	- Each job must be safe to run concurrently on several replicas:
+	  claim work with row locks (FOR UPDATE SKIP LOCKED), never in memory
+*/
+
+// Start launches all periodic jobs. Call after database.InitDB and storage.InitStorage.
+func Start(ctx context.Context) {
+	go every(ctx, "image-derivatives", 5*time.Second, func(ctx context.Context) error {
+		_, err := ProcessPendingImages(ctx, database.DB, storage.Store, 5)
+		return err
+	})
+
+	go every(ctx, "publish-schedules", 30*time.Second, func(ctx context.Context) error {
+		n, err := worksapi.ApplyDueSchedules(ctx, database.DB)
+		if n > 0 {
+			log.Printf("🗓️ publish-schedules: applied %d schedules", n)
+		}
+		return err
+	})
+
+	go every(ctx, "trash-purge", time.Hour, func(ctx context.Context) error {
+		report, err := worksapi.PurgeTrash(ctx, database.DB, worksapi.TrashRetention())
+		if report.Series > 0 || report.Artworks > 0 {
+			log.Printf("🗑️ trash-purge: purged %d series, %d artworks", report.Series, report.Artworks)
+		}
+		return err
+	})
+
+	go every(ctx, "image-gc", time.Hour, func(ctx context.Context) error {
+		report, err := SweepOrphanImages(ctx, database.DB, storage.Store, ImageGCOptions{
+			GracePeriod: ImageGCGracePeriod(),
+		})
+		if report.Deleted > 0 {
+			log.Printf("🧹 image-gc: deleted %d images (%d bytes)", report.Deleted, report.FreedBytes)
+		}
+		return err
+	})
+}
+
+// ImageGCGracePeriod returns IMAGE_GC_GRACE_HOURS (default 72h).
+func ImageGCGracePeriod() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("IMAGE_GC_GRACE_HOURS")); err == nil && v >= 0 {
+		return time.Duration(v) * time.Hour
+	}
+	return 72 * time.Hour
+}
+
+func every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := runSafe(ctx, fn); err != nil {
+			log.Printf("❌ job %s: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runSafe keeps a panicking job from taking down the API.
+func runSafe(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ job panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -1,6 +1,9 @@
 package access
 
-import "registration-app/internal/domain/plans"
+import (
+	"registration-app/internal/domain/plans"
+	"registration-app/internal/domain/site"
+)
 
 func EditorModeFromState(state AccessState) EditorMode {
 	if state == AccessFull || state == AccessTrial {
@@ -24,3 +27,24 @@ func PublicModeFromState(state AccessState, plan *plans.Plan) PublicMode {
 		return PublicLimited
 	}
 }
+
+// PublicLimitsFor returns the rules a public site is served with (nil = full site).
+// Limited public mode always has rules, also for full access on a lower tier.
+func PublicLimitsFor(state AccessState, plan *plans.Plan) *site.LimitedRules {
+	if PublicModeFromState(state, plan) == PublicFull {
+		return nil
+	}
+	if rules := site.LimitedRulesFor(string(state)); rules != nil {
+		return rules
+	}
+	return site.LimitedRulesFor(string(AccessLimited))
+}
+
+// CustomDomainAllowed mirrors the custom_domain capability of the plan tiers.
+func CustomDomainAllowed(state AccessState, plan *plans.Plan) bool {
+	if state == AccessLocked {
+		return false
+	}
+	tier := plans.PlanTier(plan)
+	return tier == "professional" || tier == "advanced"
+}
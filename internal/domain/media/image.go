@@ -2,12 +2,58 @@ package media
 
 import "time"
 
+// processing status of uploaded images (derivatives generation)
+const (
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusReady      = "ready"
+	StatusFailed     = "failed"
+)
+
 type Image struct {
 	ID           string  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 	OriginalPath string  `gorm:"not null" json:"original_path"`
 	WebpPath     *string `json:"webp_path,omitempty"`
 	AvifPath     *string `json:"avif_path,omitempty"`
 
+	// set for files uploaded through POST /media/images (nil for legacy path-only rows)
+	UserID       *uint   `gorm:"index" json:"-"`
+	StorageKey   *string `gorm:"index" json:"-"`
+	MimeType     string  `json:"mime_type,omitempty"`
+	Bytes        int64   `json:"bytes,omitempty"`
+	OriginalName string  `json:"original_name,omitempty"`
+	Width        int     `json:"width,omitempty"`
+	Height       int     `json:"height,omitempty"`
+
+	// legacy rows have nothing to process, so they default to ready
+	Status             string     `gorm:"type:text;not null;default:'ready';index" json:"status"`
+	ProcessingError    string     `json:"processing_error,omitempty"`
+	ProcessingAttempts int        `gorm:"not null;default:0" json:"-"`
+	ProcessAfter       *time.Time `gorm:"index" json:"-"` // retry backoff / lease expiry
+	ProcessedAt        *time.Time `json:"processed_at,omitempty"`
+
+	// set by the orphan sweep while no revision/block references the image
+	OrphanedAt *time.Time `gorm:"index" json:"-"`
+
+	Variants []ImageVariant `gorm:"constraint:OnDelete:CASCADE;" json:"variants,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ImageVariant is one generated derivative. (image_id, format, width) is unique,
+// so re-processing an image updates variants in place instead of adding rows.
+type ImageVariant struct {
+	ID      string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ImageID string `gorm:"type:uuid;not null;uniqueIndex:idx_image_variants_image_format_width,priority:1" json:"-"`
+	Format  string `gorm:"type:text;not null;uniqueIndex:idx_image_variants_image_format_width,priority:2" json:"format"` // "webp"
+	Width   int    `gorm:"not null;uniqueIndex:idx_image_variants_image_format_width,priority:3" json:"width"`
+	Height  int    `gorm:"not null" json:"height"`
+	Bytes   int64  `gorm:"not null" json:"bytes"`
+
+	Path       string `gorm:"not null" json:"path"`
+	StorageKey string `gorm:"not null" json:"-"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
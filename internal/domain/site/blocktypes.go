@@ -0,0 +1,314 @@
+package site
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+/*
+	Block type registry
+	-------------------
+	- Every block type the editor may store, with the JSON Schema of its props
+	- Version is the current schema version; SitePageBlock.SchemaVersion records
+	  the version the stored props were written with
+	- Changing a schema incompatibly: bump Version and add Migrations[old] that
+	  turns props of version old into version old+1. UpgradeBlocks runs them on
+	  startup, so handlers only ever see current props.
+*/
+
+// BlockMigration upgrades props by exactly one schema version.
+type BlockMigration func(props map[string]interface{}) (map[string]interface{}, error)
+
+type BlockType struct {
+	Type       string
+	Label      string
+	Version    int
+	Schema     json.RawMessage
+	Migrations map[int]BlockMigration
+
+	schema *Schema
+}
+
+const schemaMeta = `"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "object", "additionalProperties": false`
+
+var blockTypes = []BlockType{
+	{
+		Type: "hero", Label: "Hero", Version: 2,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"description": "imageUrls is a static image (e.g. of a template) used when imageId is empty.",
+			"properties": {
+				"title":    {"type": "string", "maxLength": 200},
+				"subtitle": {"type": "string", "maxLength": 300},
+				"imageId":  {"type": ["string", "null"], "format": "uuid"},
+				"imageUrls": {
+					"type": "object", "additionalProperties": false,
+					"properties": {
+						"webp": {"type": "string", "format": "uri-reference", "maxLength": 500},
+						"avif": {"type": "string", "format": "uri-reference", "maxLength": 500}
+					}
+				},
+				"alt":   {"type": "string", "maxLength": 300},
+				"align": {"enum": ["left", "center", "right"]},
+				"cta": {
+					"type": "object", "additionalProperties": false,
+					"required": ["label", "href"],
+					"properties": {
+						"label": {"type": "string", "minLength": 1, "maxLength": 80},
+						"href":  {"type": "string", "format": "uri-reference", "maxLength": 500}
+					}
+				}
+			}
+		}`),
+		Migrations: map[int]BlockMigration{
+			// v1 templates stored {"image": {"webp", "avif"}, "alt"}
+			1: func(props map[string]interface{}) (map[string]interface{}, error) {
+				if img, ok := props["image"]; ok {
+					delete(props, "image")
+					if urls, ok := img.(map[string]interface{}); ok {
+						props["imageUrls"] = urls
+					}
+				}
+				return props, nil
+			},
+		},
+	},
+	{
+		Type: "text", Label: "Text", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"required": ["body"],
+			"properties": {
+				"title":  {"type": "string", "maxLength": 200},
+				"body":   {"type": "string", "maxLength": 20000},
+				"format": {"enum": ["plain", "markdown"]}
+			}
+		}`),
+	},
+	{
+		Type: "image", Label: "Image", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"required": ["imageId"],
+			"properties": {
+				"imageId": {"type": "string", "format": "uuid"},
+				"alt":     {"type": "string", "maxLength": 300},
+				"caption": {"type": "string", "maxLength": 300},
+				"width":   {"enum": ["normal", "wide", "full"]}
+			}
+		}`),
+	},
+	{
+		Type: "gallery", Label: "Gallery", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"properties": {
+				"title":      {"type": "string", "maxLength": 200},
+				"artworkIds": {"type": "array", "maxItems": 200, "items": {"type": "string", "format": "uuid"}},
+				"imageIds":   {"type": "array", "maxItems": 100, "items": {"type": "string", "format": "uuid"}},
+				"layout":     {"enum": ["grid", "masonry", "carousel"]},
+				"columns":    {"type": "integer", "minimum": 1, "maximum": 6}
+			}
+		}`),
+	},
+	{
+		Type: "series-grid", Label: "Series grid", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"description": "Empty seriesIds shows every published series.",
+			"properties": {
+				"title":      {"type": "string", "maxLength": 200},
+				"seriesIds":  {"type": "array", "maxItems": 100, "items": {"type": "string", "format": "uuid"}},
+				"showTitles": {"type": "boolean"},
+				"columns":    {"type": "integer", "minimum": 1, "maximum": 6}
+			}
+		}`),
+	},
+	{
+		Type: "contact", Label: "Contact", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"properties": {
+				"title":   {"type": "string", "maxLength": 200},
+				"email":   {"type": "string", "format": "email", "maxLength": 254},
+				"phone":   {"type": "string", "maxLength": 50},
+				"address": {"type": "string", "maxLength": 500},
+				"links": {
+					"type": "array", "maxItems": 20,
+					"items": {
+						"type": "object", "additionalProperties": false,
+						"required": ["label", "href"],
+						"properties": {
+							"label": {"type": "string", "minLength": 1, "maxLength": 80},
+							"href":  {"type": "string", "format": "uri-reference", "maxLength": 500}
+						}
+					}
+				}
+			}
+		}`),
+	},
+	{
+		Type: "siteSettings", Label: "Site settings", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"properties": {
+				"defaultOgImage": {"type": "string", "format": "uri-reference", "maxLength": 500},
+				"languages":      {"type": "array", "maxItems": 20, "items": {"type": "string", "pattern": "^[a-z]{2}(-[A-Z]{2})?$"}}
+			}
+		}`),
+	},
+	{
+		Type: "artist", Label: "Artist", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"properties": {
+				"name":    {"type": "string", "maxLength": 200},
+				"email":   {"type": "string", "format": "email", "maxLength": 254},
+				"website": {"type": "string", "format": "uri-reference", "maxLength": 500},
+				"address": {
+					"type": "object", "additionalProperties": false,
+					"properties": {
+						"street":  {"type": "string", "maxLength": 200},
+						"zip":     {"type": "string", "maxLength": 20},
+						"city":    {"type": "string", "maxLength": 100},
+						"country": {"type": "string", "maxLength": 100}
+					}
+				}
+			}
+		}`),
+	},
+	{
+		Type: "pageMeta", Label: "Page meta", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"properties": {
+				"pageLabel":  {"type": "string", "maxLength": 200},
+				"artistName": {"type": "string", "maxLength": 200}
+			}
+		}`),
+	},
+	{
+		Type: "seo", Label: "SEO", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"properties": {
+				"title":       {"type": "string", "maxLength": 200},
+				"description": {"type": "string", "maxLength": 500},
+				"ogImage":     {"type": "string", "format": "uri-reference", "maxLength": 500}
+			}
+		}`),
+	},
+	{
+		Type: "divider", Label: "Divider", Version: 1,
+		Schema: json.RawMessage(`{` + schemaMeta + `,
+			"properties": {
+				"style": {"enum": ["line", "space"]},
+				"size":  {"enum": ["s", "m", "l"]}
+			}
+		}`),
+	},
+}
+
+var blockTypesByName = map[string]*BlockType{}
+
+func init() {
+	for i := range blockTypes {
+		bt := &blockTypes[i]
+		s, err := CompileSchema(bt.Schema)
+		if err != nil {
+			panic(fmt.Sprintf("site: block type %s: %v", bt.Type, err))
+		}
+		for v := 1; v < bt.Version; v++ {
+			if bt.Migrations[v] == nil {
+				panic(fmt.Sprintf("site: block type %s: no migration from version %d", bt.Type, v))
+			}
+		}
+		bt.schema = s
+		blockTypesByName[bt.Type] = bt
+	}
+}
+
+// BlockTypes lists the registry sorted by type.
+func BlockTypes() []BlockType {
+	out := append([]BlockType(nil), blockTypes...)
+	sort.Slice(out, func(i, j int) bool { return out[i].Type < out[j].Type })
+	return out
+}
+
+func LookupBlockType(typ string) (*BlockType, bool) {
+	bt, ok := blockTypesByName[typ]
+	return bt, ok
+}
+
+// ValidateBlockProps checks props against the current schema of typ.
+func ValidateBlockProps(typ string, props json.RawMessage) error {
+	bt, ok := LookupBlockType(typ)
+	if !ok {
+		return fmt.Errorf("block type unknown")
+	}
+	if err := bt.schema.ValidateJSON(props); err != nil {
+		return fmt.Errorf("block props %s", err.Error())
+	}
+	return nil
+}
+
+// UpgradeBlockProps runs the migrations from version up to the current one.
+// Props of unknown types or already current versions are returned unchanged.
+func UpgradeBlockProps(typ string, version int, props json.RawMessage) (json.RawMessage, int, error) {
+	bt, ok := LookupBlockType(typ)
+	if !ok || version >= bt.Version {
+		return props, version, nil
+	}
+	if version < 1 {
+		version = 1
+	}
+
+	m := map[string]interface{}{}
+	if len(bytes.TrimSpace(props)) > 0 {
+		if err := json.Unmarshal(props, &m); err != nil {
+			return nil, version, err
+		}
+	}
+	for ; version < bt.Version; version++ {
+		next, err := bt.Migrations[version](m)
+		if err != nil {
+			return nil, version, fmt.Errorf("%s v%d: %w", typ, version, err)
+		}
+		m = next
+	}
+	out, err := json.Marshal(m)
+	if err != nil {
+		return nil, version, err
+	}
+	return out, version, nil
+}
+
+// UpgradeBlocks upgrades every stored block whose schema version is behind
+// the registry. Blocks that fail to upgrade are logged and left as they are.
+//
+// IMPORTANT: pass db in, do NOT import registration-app/database here.
+func UpgradeBlocks(db *gorm.DB) (int, error) {
+	upgraded := 0
+	for _, bt := range blockTypes {
+		if bt.Version <= 1 {
+			continue
+		}
+		var blocks []SitePageBlock
+		err := db.Where("type = ? AND schema_version < ?", bt.Type, bt.Version).
+			FindInBatches(&blocks, 200, func(tx *gorm.DB, _ int) error {
+				for _, b := range blocks {
+					props, version, err := UpgradeBlockProps(b.Type, b.SchemaVersion, b.Props)
+					if err != nil {
+						log.Printf("❌ Block %s upgrade failed: %v", b.ID, err)
+						continue
+					}
+					if err := db.Model(&SitePageBlock{}).
+						Where("id = ? AND schema_version = ?", b.ID, b.SchemaVersion).
+						UpdateColumns(map[string]interface{}{"props": props, "schema_version": version}).Error; err != nil {
+						return err
+					}
+					upgraded++
+				}
+				return nil
+			}).Error
+		if err != nil {
+			return upgraded, err
+		}
+	}
+	return upgraded, nil
+}
@@ -0,0 +1,37 @@
+package site
+
+import "gorm.io/gorm"
+
+// AdoptLegacyBlocks moves blocks written without a revision (pages from before
+// revisions, or seeded straight into site_page_blocks) into revisions: every
+// page without one gets a revision, published for "published" pages and draft
+// otherwise, and blocks without revision join the page's current revision.
+// It only touches rows that still need it, so it is safe to run at any time.
+//
+// IMPORTANT: pass db in, do NOT import registration-app/database here.
+func AdoptLegacyBlocks(db *gorm.DB) error {
+	if err := db.Exec(`INSERT INTO site_page_revisions (page_id, published_at, created_at, updated_at)
+		SELECT p.id, CASE WHEN p.status = 'published' THEN p.updated_at END, p.created_at, p.updated_at
+		FROM site_pages p
+		WHERE p.published_revision_id IS NULL AND p.draft_revision_id IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM site_page_revisions r WHERE r.page_id = p.id)`).Error; err != nil {
+		return err
+	}
+	// pages without pointers get their latest revision
+	if err := db.Exec(`UPDATE site_pages p SET
+			published_revision_id = CASE WHEN r.published_at IS NOT NULL THEN r.id END,
+			draft_revision_id     = CASE WHEN r.published_at IS NULL THEN r.id END
+		FROM (
+			SELECT DISTINCT ON (page_id) id, page_id, published_at
+			FROM site_page_revisions
+			ORDER BY page_id, created_at DESC, id DESC
+		) r
+		WHERE r.page_id = p.id
+		  AND p.published_revision_id IS NULL AND p.draft_revision_id IS NULL`).Error; err != nil {
+		return err
+	}
+	return db.Exec(`UPDATE site_page_blocks b
+		SET revision_id = COALESCE(p.published_revision_id, p.draft_revision_id)
+		FROM site_pages p
+		WHERE p.id = b.page_id AND b.revision_id IS NULL`).Error
+}
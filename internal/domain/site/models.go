@@ -24,15 +24,36 @@ type SitePage struct {
 	ID string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 
 	OwnerType string `gorm:"not null;index" json:"owner_type"`
-	UserID    *uint  `gorm:"index" json:"-"`
+	UserID    *uint  `gorm:"index;uniqueIndex:idx_site_pages_user_slug_lang,priority:1" json:"-"` // one page per slug+lang and user
 
 	TemplateID *string `gorm:"type:uuid;index" json:"template_id,omitempty"`
 
-	Slug   string `gorm:"not null;index" json:"slug"`
-	Lang   string `gorm:"not null;index" json:"lang"`
-	Status string `gorm:"not null;default:'draft'" json:"status"`
+	Slug   string `gorm:"not null;index;uniqueIndex:idx_site_pages_user_slug_lang,priority:2" json:"slug"`
+	Lang   string `gorm:"not null;index;uniqueIndex:idx_site_pages_user_slug_lang,priority:3" json:"lang"`
+	Status string `gorm:"not null;default:'draft'" json:"status"` // mirrors PublishedRevisionID: "published" | "draft"
 
-	Blocks []SitePageBlock `gorm:"foreignKey:PageID;references:ID;constraint:OnDelete:CASCADE;" json:"blocks,omitempty"`
+	// content lives in revisions, like works.Series: edits go to the draft,
+	// publishing moves the published pointer
+	PublishedRevisionID *string           `gorm:"type:uuid;index" json:"-"`
+	DraftRevisionID     *string           `gorm:"type:uuid;index" json:"-"`
+	DraftRevision       *SitePageRevision `gorm:"foreignKey:DraftRevisionID" json:"-"`
+	PublishedRevision   *SitePageRevision `gorm:"foreignKey:PublishedRevisionID" json:"-"`
+
+	// blocks of every revision (cascade only); read content through the revision pointers
+	Blocks []SitePageBlock `gorm:"foreignKey:PageID;references:ID;constraint:OnDelete:CASCADE;" json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SitePageRevision struct {
+	ID     string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	PageID string `gorm:"type:uuid;not null;index" json:"page_id"`
+
+	Blocks []SitePageBlock `gorm:"foreignKey:RevisionID;references:ID;constraint:OnDelete:CASCADE;" json:"blocks,omitempty"`
+
+	// set when the revision went live; published revisions are immutable history
+	PublishedAt *time.Time `gorm:"index" json:"published_at,omitempty"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -41,11 +62,13 @@ type SitePage struct {
 type SitePageBlock struct {
 	ID string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 
-	PageID    string `gorm:"type:uuid;not null;index" json:"page_id"`
-	SortIndex int    `gorm:"not null;default:0;index" json:"sort_index"`
+	PageID     string  `gorm:"type:uuid;not null;index" json:"page_id"`
+	RevisionID *string `gorm:"type:uuid;index" json:"revision_id,omitempty"`
+	SortIndex  int     `gorm:"not null;default:0;index" json:"sort_index"`
 
-	Type  string          `gorm:"not null;index" json:"type"`
-	Props json.RawMessage `gorm:"type:jsonb;not null;default:'{}'" json:"props"`
+	Type          string          `gorm:"not null;index" json:"type"`
+	Props         json.RawMessage `gorm:"type:jsonb;not null;default:'{}'" json:"props"`
+	SchemaVersion int             `gorm:"not null;default:1" json:"schema_version"` // see BlockType.Version
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
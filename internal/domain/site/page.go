@@ -0,0 +1,45 @@
+package site
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	PageDraft     = "draft"
+	PagePublished = "published"
+
+	MaxPageSlugLen   = 80
+	MaxBlocksPerPage = 200
+)
+
+var (
+	pageSlugRe = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	langRe     = regexp.MustCompile(`^[a-z]{2,3}(?:-[a-z0-9]{2,8})*$`)
+)
+
+// NormalizePageSlug trims/lowercases a page slug and checks it is URL-safe.
+// Example: " About-Me " -> "about-me"
+func NormalizePageSlug(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "", fmt.Errorf("slug required")
+	}
+	if len(s) > MaxPageSlugLen || !pageSlugRe.MatchString(s) {
+		return "", fmt.Errorf("invalid slug")
+	}
+	return s, nil
+}
+
+// NormalizeLang maps "de_AT" to "de-at" and checks the shape of the tag.
+func NormalizeLang(s string) (string, error) {
+	s = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "_", "-")
+	if s == "" {
+		return "", fmt.Errorf("lang required")
+	}
+	if !langRe.MatchString(s) {
+		return "", fmt.Errorf("invalid lang")
+	}
+	return s, nil
+}
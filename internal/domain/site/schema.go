@@ -0,0 +1,304 @@
+package site
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+	JSON Schema (subset)
+	--------------------
+	- Just enough of draft 2020-12 for block props: type, properties, required,
+	  additionalProperties (bool), items, enum, min/maxLength, pattern, format
+	  (uuid, email, uri-reference), minimum/maximum, min/maxItems
+	- Unknown keywords are rejected when compiling, so a schema never silently
+	  relies on something that is not enforced
+*/
+
+type Schema struct {
+	Meta        string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type schemaTypes   `json:"type,omitempty"`
+	Enum []interface{} `json:"enum,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+	MaxItems *int    `json:"maxItems,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Format    string `json:"format,omitempty"`
+
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes accepts "type": "string" as well as "type": ["string", "null"].
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+func (t schemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+var schemaFormats = map[string]bool{"uuid": true, "email": true, "uri-reference": true}
+
+// CompileSchema parses a schema document and checks it only uses supported keywords.
+func CompileSchema(src []byte) (*Schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.DisallowUnknownFields()
+	var s Schema
+	if err := dec.Decode(&s); err != nil {
+		return nil, err
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Schema) compile() error {
+	for _, t := range s.Type {
+		switch t {
+		case "object", "array", "string", "number", "integer", "boolean", "null":
+		default:
+			return fmt.Errorf("unsupported type %q", t)
+		}
+	}
+	if s.Format != "" && !schemaFormats[s.Format] {
+		return fmt.Errorf("unsupported format %q", s.Format)
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = re
+	}
+	for name, p := range s.Properties {
+		if err := p.compile(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// ValidateJSON decodes doc and validates it. Errors read "/path: message".
+func (s *Schema) ValidateJSON(doc []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("/: invalid JSON")
+	}
+	return s.validate("", v)
+}
+
+func (s *Schema) validate(path string, v interface{}) error {
+	fail := func(format string, args ...interface{}) error {
+		p := path
+		if p == "" {
+			p = "/"
+		}
+		return fmt.Errorf("%s: %s", p, fmt.Sprintf(format, args...))
+	}
+
+	if len(s.Type) > 0 && !s.Type.matches(v) {
+		return fail("must be %s", strings.Join(s.Type, " or "))
+	}
+	if len(s.Enum) > 0 && !enumContains(s.Enum, v) {
+		return fail("must be one of %s", enumList(s.Enum))
+	}
+
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := x[name]; !ok {
+				return fail("%s is required", name)
+			}
+		}
+		names := make([]string, 0, len(x))
+		for name := range x {
+			names = append(names, name)
+		}
+		sort.Strings(names) // stable error messages
+		for _, name := range names {
+			val := x[name]
+			p, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fail("unknown property %s", name)
+				}
+				continue
+			}
+			if err := p.validate(path+"/"+name, val); err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		if s.MinItems != nil && len(x) < *s.MinItems {
+			return fail("needs at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(x) > *s.MaxItems {
+			return fail("allows at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range x {
+				if err := s.Items.validate(fmt.Sprintf("%s/%d", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+
+	case string:
+		n := utf8.RuneCountInString(x)
+		if s.MinLength != nil && n < *s.MinLength {
+			return fail("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return fail("must be at most %d characters", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(x) {
+			return fail("has an invalid format")
+		}
+		if s.Format != "" && !validFormat(s.Format, x) {
+			return fail("must be a valid %s", s.Format)
+		}
+
+	case json.Number:
+		f, _ := x.Float64()
+		if s.Minimum != nil && f < *s.Minimum {
+			return fail("must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			return fail("must be <= %v", *s.Maximum)
+		}
+	}
+	return nil
+}
+
+func (t schemaTypes) matches(v interface{}) bool {
+	for _, want := range t {
+		switch x := v.(type) {
+		case map[string]interface{}:
+			if want == "object" {
+				return true
+			}
+		case []interface{}:
+			if want == "array" {
+				return true
+			}
+		case string:
+			if want == "string" {
+				return true
+			}
+		case bool:
+			if want == "boolean" {
+				return true
+			}
+		case nil:
+			if want == "null" {
+				return true
+			}
+		case json.Number:
+			if want == "number" {
+				return true
+			}
+			if want == "integer" {
+				if _, err := x.Int64(); err == nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func enumContains(enum []interface{}, v interface{}) bool {
+	for _, e := range enum {
+		switch x := v.(type) {
+		case json.Number:
+			if f, ok := e.(float64); ok {
+				if g, err := x.Float64(); err == nil && f == g {
+					return true
+				}
+			}
+		default:
+			if e == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func enumList(enum []interface{}) string {
+	parts := make([]string, 0, len(enum))
+	for _, e := range enum {
+		parts = append(parts, fmt.Sprint(e))
+	}
+	return strings.Join(parts, ", ")
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func validFormat(format, s string) bool {
+	switch format {
+	case "uuid":
+		return uuidRe.MatchString(s)
+	case "email":
+		a, err := mail.ParseAddress(s)
+		return err == nil && a.Address == s
+	case "uri-reference":
+		// links end up in href attributes: no javascript:/data: schemes
+		if strings.ContainsAny(s, " \t\r\n") {
+			return false
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			return false
+		}
+		switch strings.ToLower(u.Scheme) {
+		case "", "http", "https", "mailto", "tel":
+			return true
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package site
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSchemaKeywords(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		doc    string
+		err    string // substring of the error, "" = valid
+	}{
+		{"type", `{"type": "string"}`, `1`, "/: must be string"},
+		{"type union string", `{"type": ["string", "null"]}`, `"x"`, ""},
+		{"type union null", `{"type": ["string", "null"]}`, `null`, ""},
+		{"type union mismatch", `{"type": ["string", "null"]}`, `true`, "must be string or null"},
+		{"integer", `{"type": "integer"}`, `1.5`, "must be integer"},
+		{"enum", `{"enum": ["a", "b"]}`, `"b"`, ""},
+		{"enum mismatch", `{"enum": ["a", "b"]}`, `"c"`, "must be one of a, b"},
+		{"enum number", `{"enum": [1, 2]}`, `2`, ""},
+		{"format uuid", `{"type": "string", "format": "uuid"}`, `"0b7c1a8e-3f2d-4c5b-9a1e-2d3c4b5a6f70"`, ""},
+		{"format uuid invalid", `{"type": "string", "format": "uuid"}`, `"0b7c1a8e"`, "must be a valid uuid"},
+		{"format uri-reference path", `{"type": "string", "format": "uri-reference"}`, `"/works#top"`, ""},
+		{"format uri-reference https", `{"type": "string", "format": "uri-reference"}`, `"https://example.com"`, ""},
+		{"format uri-reference javascript", `{"type": "string", "format": "uri-reference"}`, `"javascript:alert(1)"`, "must be a valid uri-reference"},
+		{"format uri-reference space", `{"type": "string", "format": "uri-reference"}`, `"/a b"`, "must be a valid uri-reference"},
+		{"required", `{"type": "object", "required": ["body"]}`, `{}`, "/: body is required"},
+		{"additionalProperties false", `{"type": "object", "additionalProperties": false, "properties": {"a": {}}}`, `{"b": 1}`, "unknown property b"},
+		{"additionalProperties default", `{"type": "object", "properties": {"a": {}}}`, `{"b": 1}`, ""},
+		{"nested path", `{"type": "object", "properties": {"a": {"type": "array", "items": {"type": "string"}}}}`, `{"a": ["x", 1]}`, "/a/1: must be string"},
+		{"maxItems", `{"type": "array", "maxItems": 2}`, `[1, 2, 3]`, "allows at most 2 items"},
+		{"maxItems ok", `{"type": "array", "maxItems": 2}`, `[1, 2]`, ""},
+		{"maxLength runes", `{"type": "string", "maxLength": 3}`, `"äöü"`, ""},
+		{"minimum", `{"type": "integer", "minimum": 1}`, `0`, "must be >= 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := CompileSchema([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.ValidateJSON([]byte(tt.doc))
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("unexpected error %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Fatalf("error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCompileSchemaRejectsUnsupported(t *testing.T) {
+	for _, src := range []string{
+		`{"type": "date"}`,
+		`{"format": "hostname"}`,
+		`{"oneOf": []}`,
+		`{"properties": {"a": {"pattern": "("}}}`,
+	} {
+		if _, err := CompileSchema([]byte(src)); err == nil {
+			t.Errorf("CompileSchema(%s) compiled", src)
+		}
+	}
+}
+
+func TestBlockSchemas(t *testing.T) {
+	const id = "0b7c1a8e-3f2d-4c5b-9a1e-2d3c4b5a6f70"
+	tests := []struct {
+		typ, props string
+		ok         bool
+	}{
+		{"hero", `{"title": "Hi", "imageId": null, "cta": {"label": "Works", "href": "/works"}}`, true},
+		{"hero", `{"imageUrls": {"webp": "/images/a.webp", "avif": "/images/a.avif"}, "alt": "Background"}`, true},
+		{"hero", `{"image": {"webp": "/images/a.webp"}}`, false},
+		{"hero", `{"cta": {"label": "Works"}}`, false},
+		{"hero", `{"align": "top"}`, false},
+		{"gallery", `{"imageIds": ["` + id + `"], "layout": "grid", "columns": 3}`, true},
+		{"gallery", `{"imageIds": ["nope"]}`, false},
+		{"gallery", `{"columns": 7}`, false},
+		{"gallery", `{"artworkIds": [` + strings.TrimSuffix(strings.Repeat(`"`+id+`",`, 201), ",") + `]}`, false},
+		{"seo", `{"title": "Georg Fieger", "description": "Paintings", "ogImage": "/social-preview.jpg"}`, true},
+		{"artist", `{"name": "A", "email": "info@example.com", "address": {"city": "City"}}`, true},
+		{"siteSettings", `{"languages": ["de", "en"]}`, true},
+		{"siteSettings", `{"languages": ["german"]}`, false},
+	}
+	for _, tt := range tests {
+		err := ValidateBlockProps(tt.typ, json.RawMessage(tt.props))
+		if (err == nil) != tt.ok {
+			t.Errorf("ValidateBlockProps(%s, %s) = %v, want ok=%v", tt.typ, tt.props, err, tt.ok)
+		}
+	}
+}
+
+func TestUpgradeBlockPropsHero(t *testing.T) {
+	legacy := json.RawMessage(`{"image": {"webp": "/images/a.webp", "avif": "/images/a.avif"}, "alt": "Background"}`)
+
+	props, version, err := UpgradeBlockProps("hero", 1, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bt, _ := LookupBlockType("hero")
+	if version != bt.Version {
+		t.Fatalf("version = %d, want %d", version, bt.Version)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(props, &got); err != nil {
+		t.Fatal(err)
+	}
+	urls, _ := got["imageUrls"].(map[string]interface{})
+	if _, ok := got["image"]; ok || urls["webp"] != "/images/a.webp" || urls["avif"] != "/images/a.avif" || got["alt"] != "Background" {
+		t.Fatalf("props = %s", props)
+	}
+	if err := ValidateBlockProps("hero", props); err != nil {
+		t.Fatalf("upgraded props invalid: %v", err)
+	}
+
+	// current props are returned unchanged
+	if same, v, _ := UpgradeBlockProps("hero", bt.Version, legacy); v != bt.Version || string(same) != string(legacy) {
+		t.Fatalf("current props changed: %s v%d", same, v)
+	}
+}
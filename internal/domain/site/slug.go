@@ -2,6 +2,8 @@ package site
 
 import (
 	"fmt"
+	"net"
+	"os"
 	"regexp"
 	"strings"
 
@@ -78,8 +80,36 @@ func EnsureSiteSlug(db *gorm.DB, user *users.User) (string, error) {
 	return slug, nil
 }
 
+// BaseDomain is the platform domain public sites live under (SITE_BASE_DOMAIN).
+func BaseDomain() string {
+	if d := strings.Trim(strings.ToLower(os.Getenv("SITE_BASE_DOMAIN")), ". "); d != "" {
+		return d
+	}
+	return "yourplatform.com"
+}
+
 // BuildPublicURL builds the public site URL from a slug.
 // Example: "john-doe-32" -> "https://john-doe-32.yourplatform.com"
 func BuildPublicURL(slug string) string {
-	return "https://" + slug + ".yourplatform.com"
+	return "https://" + slug + "." + BaseDomain()
+}
+
+// SlugFromHost returns the site slug of a platform subdomain host.
+// Example: "john-doe-32.yourplatform.com:443" -> "john-doe-32", true
+func SlugFromHost(host string) (string, bool) {
+	host = NormalizeHost(host)
+	sub := strings.TrimSuffix(host, "."+BaseDomain())
+	if sub == host || sub == "" || strings.Contains(sub, ".") || sub == "www" {
+		return "", false
+	}
+	return sub, true
+}
+
+// NormalizeHost lowercases host and strips the port and a trailing dot.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
 }
@@ -3,6 +3,8 @@ package works
 import (
 	"registration-app/internal/domain/media"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Artwork struct {
@@ -15,7 +17,16 @@ type Artwork struct {
 	SeriesID  string `gorm:"type:uuid;not null;index:idx_artworks_series_sort,priority:1"`
 
 	IDLocked bool `gorm:"not null;default:false" json:"id_locked"`
-	Sold     bool `gorm:"not null;default:false" json:"sold"`
+	Sold     bool `gorm:"not null;default:false;index" json:"sold"`
+
+	// multiples: numbered editions + artist proofs, one ArtworkEdition row each;
+	// Sold is kept in sync (= sold out) for clients that only know the flag
+	EditionSize  int              `gorm:"not null;default:0" json:"edition_size"`
+	ArtistProofs int              `gorm:"not null;default:0" json:"artist_proofs"`
+	Editions     []ArtworkEdition `gorm:"constraint:OnDelete:CASCADE;" json:"editions,omitempty"`
+
+	// tags and categories (join table artwork_terms)
+	Terms []Term `gorm:"many2many:artwork_terms;" json:"terms,omitempty"`
 
 	ImageID *string      `gorm:"type:uuid" json:"image_id,omitempty"`
 	Image   *media.Image `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"image,omitempty"`
@@ -32,6 +43,13 @@ type Artwork struct {
 	DraftRevision       *ArtworkRevision `gorm:"foreignKey:DraftRevisionID"`
 	PublishedRevision   *ArtworkRevision `gorm:"foreignKey:PublishedRevisionID"`
 
+	// pending schedules, applied once by the publish scheduler and then cleared
+	PublishAt   *time.Time `gorm:"index" json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `gorm:"index" json:"unpublish_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// soft delete: set = in trash (GET /trash), hard-deleted after the retention window
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
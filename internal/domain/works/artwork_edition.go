@@ -0,0 +1,102 @@
+package works
+
+import (
+	"strconv"
+	"time"
+)
+
+// Edition kinds: numbered copies 1..EditionSize and artist proofs 1..ArtistProofs.
+const (
+	EditionNumbered    = "edition"
+	EditionArtistProof = "ap"
+)
+
+const (
+	EditionAvailable = "available"
+	EditionReserved  = "reserved"
+	EditionSold      = "sold"
+	EditionGifted    = "gifted"
+)
+
+// Artwork availability, derived from Sold or (for multiples) the editions.
+const (
+	AvailabilityAvailable = "available"
+	AvailabilityReserved  = "reserved" // nothing available, something reserved
+	AvailabilitySold      = "sold"     // sold out (sold or gifted)
+)
+
+func ValidEditionStatus(s string) bool {
+	return s == EditionAvailable || s == EditionReserved || s == EditionSold || s == EditionGifted
+}
+
+// ArtworkEdition is one physical copy of a multiple. Inventory lives on the
+// artwork identity, not on revisions: it is never drafted or published.
+type ArtworkEdition struct {
+	ID        string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ArtworkID string `gorm:"type:uuid;not null;uniqueIndex:idx_artwork_editions_number,priority:1" json:"-"`
+
+	Kind   string `gorm:"type:text;not null;default:'edition';uniqueIndex:idx_artwork_editions_number,priority:2" json:"kind"`
+	Number int    `gorm:"not null;uniqueIndex:idx_artwork_editions_number,priority:3" json:"number"`
+
+	Status   string     `gorm:"type:text;not null;default:'available';index" json:"status"`
+	BuyerRef string     `json:"buyer_ref,omitempty"` // invoice / contact reference, never public
+	SoldAt   *time.Time `json:"sold_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Label renders "3/25" or "AP 1/3".
+func (e ArtworkEdition) Label(editionSize, artistProofs int) string {
+	if e.Kind == EditionArtistProof {
+		return "AP " + strconv.Itoa(e.Number) + "/" + strconv.Itoa(artistProofs)
+	}
+	return strconv.Itoa(e.Number) + "/" + strconv.Itoa(editionSize)
+}
+
+type EditionCounts struct {
+	Available int
+	Reserved  int
+	Sold      int
+	Gifted    int
+}
+
+func CountEditions(editions []ArtworkEdition) EditionCounts {
+	var c EditionCounts
+	for _, e := range editions {
+		switch e.Status {
+		case EditionAvailable:
+			c.Available++
+		case EditionReserved:
+			c.Reserved++
+		case EditionSold:
+			c.Sold++
+		case EditionGifted:
+			c.Gifted++
+		}
+	}
+	return c
+}
+
+// IsMultiple reports whether the artwork is tracked per edition.
+func (a Artwork) IsMultiple() bool {
+	return a.EditionSize > 0 || a.ArtistProofs > 0
+}
+
+// Availability derives the overall state; expects a.Editions loaded for multiples.
+func (a Artwork) Availability() string {
+	if !a.IsMultiple() {
+		if a.Sold {
+			return AvailabilitySold
+		}
+		return AvailabilityAvailable
+	}
+	c := CountEditions(a.Editions)
+	switch {
+	case c.Available > 0:
+		return AvailabilityAvailable
+	case c.Reserved > 0:
+		return AvailabilityReserved
+	}
+	return AvailabilitySold
+}
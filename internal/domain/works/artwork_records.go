@@ -0,0 +1,92 @@
+package works
+
+import "time"
+
+// Provenance, exhibition history and literature of an artwork. The rows belong
+// to an ArtworkRevision like ArtworkI18nRevision: they are cloned into every new
+// draft and only become public when that revision is published.
+//
+// Dates are partial ISO dates ("1998", "1998-05", "1998-05-14"), so they still
+// sort as text; an empty DateTo means open-ended / unknown.
+
+type ArtworkProvenanceRevision struct {
+	ID                string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ArtworkRevisionID string `gorm:"type:uuid;index;not null" json:"-"`
+	SortIndex         int    `gorm:"not null;default:0" json:"sort_index"`
+
+	Owner    string `gorm:"not null" json:"owner"` // person or collection, as printed
+	Location string `json:"location,omitempty"`
+	DateFrom string `json:"date_from,omitempty"`
+	DateTo   string `json:"date_to,omitempty"`
+
+	I18n []ArtworkProvenanceI18nRevision `gorm:"constraint:OnDelete:CASCADE;" json:"i18n,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ArtworkProvenanceI18nRevision struct {
+	ArtworkProvenanceRevisionID string `gorm:"type:uuid;primaryKey"`
+	Lang                        string `gorm:"primaryKey"`
+
+	Note string `json:"note,omitempty"` // e.g. "acquired from the artist"
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ArtworkExhibitionRevision struct {
+	ID                string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ArtworkRevisionID string `gorm:"type:uuid;index;not null" json:"-"`
+	SortIndex         int    `gorm:"not null;default:0" json:"sort_index"`
+
+	Venue    string `gorm:"not null" json:"venue"`
+	City     string `json:"city,omitempty"`
+	Country  string `json:"country,omitempty"`
+	DateFrom string `json:"date_from,omitempty"`
+	DateTo   string `json:"date_to,omitempty"`
+
+	I18n []ArtworkExhibitionI18nRevision `gorm:"constraint:OnDelete:CASCADE;" json:"i18n,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ArtworkExhibitionI18nRevision struct {
+	ArtworkExhibitionRevisionID string `gorm:"type:uuid;primaryKey"`
+	Lang                        string `gorm:"primaryKey"`
+
+	Title string `json:"title,omitempty"` // exhibition title
+	Note  string `json:"note,omitempty"`  // e.g. "solo exhibition", "cat. no. 12"
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ArtworkLiteratureRevision struct {
+	ID                string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ArtworkRevisionID string `gorm:"type:uuid;index;not null" json:"-"`
+	SortIndex         int    `gorm:"not null;default:0" json:"sort_index"`
+
+	Author    string `json:"author,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Pages     string `json:"pages,omitempty"` // "p. 45, ill."
+	URL       string `json:"url,omitempty"`
+
+	I18n []ArtworkLiteratureI18nRevision `gorm:"constraint:OnDelete:CASCADE;" json:"i18n,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ArtworkLiteratureI18nRevision struct {
+	ArtworkLiteratureRevisionID string `gorm:"type:uuid;primaryKey"`
+	Lang                        string `gorm:"primaryKey"`
+
+	Title string `json:"title,omitempty"` // publication title
+	Note  string `json:"note,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -17,8 +17,31 @@ type ArtworkRevision struct {
 	SizeCM string `gorm:"column:size_cm" json:"size_cm,omitempty"`
 	Price  string `json:"price,omitempty"`
 
+	// structured fields (see fields.go); parsed from the strings above when not given
+	YearFrom *int `gorm:"index" json:"year_from,omitempty"`
+	YearTo   *int `json:"year_to,omitempty"` // nil = single year
+
+	MediumKey string `gorm:"index" json:"medium_key,omitempty"` // Mediums vocabulary
+
+	Height        *float64 `json:"height,omitempty"`
+	Width         *float64 `json:"width,omitempty"`
+	Depth         *float64 `json:"depth,omitempty"`
+	DimensionUnit string   `gorm:"not null;default:'cm'" json:"dimension_unit"`
+
+	PriceMinor      *int64 `gorm:"index" json:"price_minor,omitempty"` // minor units (cents)
+	PriceCurrency   string `gorm:"size:3" json:"price_currency,omitempty"`
+	PriceVisibility string `gorm:"not null;default:'show'" json:"price_visibility"`
+
 	I18n []ArtworkI18nRevision `gorm:"constraint:OnDelete:CASCADE;" json:"i18n,omitempty"`
 
+	// provenance, exhibitions, literature (see artwork_records.go)
+	Provenance  []ArtworkProvenanceRevision `gorm:"constraint:OnDelete:CASCADE;" json:"provenance,omitempty"`
+	Exhibitions []ArtworkExhibitionRevision `gorm:"constraint:OnDelete:CASCADE;" json:"exhibitions,omitempty"`
+	Literature  []ArtworkLiteratureRevision `gorm:"constraint:OnDelete:CASCADE;" json:"literature,omitempty"`
+
+	// set when the revision went live; published revisions are immutable history
+	PublishedAt *time.Time `gorm:"index" json:"published_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,78 @@
+package works
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"registration-app/internal/domain/media"
+)
+
+// ArtworkCertificate is an issued certificate of authenticity. The printed
+// data is frozen at issue time and signed, so a certificate always renders and
+// verifies the same; changed artwork data needs an explicit reissue, which
+// revokes the previous certificate. At most one active certificate exists per
+// artwork and edition copy (EditionID "" for unique works).
+//
+// No foreign keys to the artwork: certificates outlive purged artworks.
+type ArtworkCertificate struct {
+	ID   string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Code string `gorm:"type:text;not null;uniqueIndex" json:"code"` // printed and in the QR code
+
+	UserID    uint   `gorm:"not null;index" json:"-"`
+	ArtworkID string `gorm:"type:uuid;not null;uniqueIndex:idx_artwork_certificates_active,where:revoked_at IS NULL,priority:1" json:"artwork_id"`
+	EditionID string `gorm:"type:text;not null;default:'';uniqueIndex:idx_artwork_certificates_active,where:revoked_at IS NULL,priority:2" json:"edition_id,omitempty"`
+
+	// frozen at issue time
+	ArtworkRevisionID string       `gorm:"type:uuid" json:"artwork_revision_id"`
+	Artist            string       `gorm:"not null" json:"artist"`
+	Title             string       `gorm:"not null" json:"title"`
+	Year              string       `json:"year"`
+	Medium            string       `json:"medium"`
+	Size              string       `json:"size"`
+	Edition           string       `json:"edition,omitempty"` // "3/25", "AP 1/3"
+	Lang              string       `gorm:"not null" json:"lang"`
+	ImageID           *string      `gorm:"type:uuid" json:"image_id,omitempty"`
+	Image             *media.Image `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+
+	Signature string     `gorm:"not null" json:"-"` // hex HMAC-SHA256 of Payload()
+	IssuedAt  time.Time  `gorm:"not null" json:"issued_at"`
+	RevokedAt *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Payload is the signed content: every printed field plus the image shown on
+// verification and the language, in a fixed order. A lost image (ImageID set
+// to NULL) therefore fails verification instead of passing unsigned.
+func (c ArtworkCertificate) Payload() []byte {
+	imageID := ""
+	if c.ImageID != nil {
+		imageID = *c.ImageID
+	}
+	b, _ := json.Marshal([]string{
+		c.Code, c.ArtworkID, c.EditionID, c.Artist, c.Title, c.Year, c.Medium, c.Size, c.Edition,
+		c.IssuedAt.UTC().Format(time.RFC3339), imageID, c.Lang,
+	})
+	return b
+}
+
+func (c ArtworkCertificate) Sign(secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(c.Payload())
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the stored signature against the stored data.
+func (c ArtworkCertificate) Verify(secret []byte) bool {
+	want, err := hex.DecodeString(c.Signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(c.Payload())
+	return hmac.Equal(want, mac.Sum(nil))
+}
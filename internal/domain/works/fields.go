@@ -0,0 +1,350 @@
+package works
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Structured artwork fields. The free-text columns (Year, Medium, SizeCM, Price)
+// stay as the artist typed them; the structured ones are what we sort, filter
+// and format with.
+
+const (
+	UnitCM = "cm"
+	UnitMM = "mm"
+	UnitIN = "in"
+)
+
+const (
+	PriceShow      = "show"
+	PriceOnRequest = "on_request"
+	PriceHidden    = "hidden"
+)
+
+const DefaultCurrency = "EUR"
+
+func ValidDimensionUnit(u string) bool {
+	return u == UnitCM || u == UnitMM || u == UnitIN
+}
+
+func ValidPriceVisibility(v string) bool {
+	return v == PriceShow || v == PriceOnRequest || v == PriceHidden
+}
+
+// isoCurrencies are the ISO 4217 codes we accept (prices and free-text parsing).
+var isoCurrencies = map[string]bool{
+	"AED": true, "ARS": true, "AUD": true, "BGN": true, "BRL": true, "CAD": true, "CHF": true,
+	"CLP": true, "CNY": true, "COP": true, "CZK": true, "DKK": true, "EGP": true, "EUR": true,
+	"GBP": true, "HKD": true, "HUF": true, "IDR": true, "ILS": true, "INR": true, "ISK": true,
+	"JPY": true, "KRW": true, "MAD": true, "MXN": true, "MYR": true, "NOK": true, "NZD": true,
+	"PEN": true, "PHP": true, "PLN": true, "QAR": true, "RON": true, "RSD": true, "SAR": true,
+	"SEK": true, "SGD": true, "THB": true, "TRY": true, "TWD": true, "UAH": true, "USD": true,
+	"VND": true, "ZAR": true,
+}
+
+func ValidCurrency(c string) bool {
+	return isoCurrencies[c]
+}
+
+// CurrencyMinorDigits is the number of decimals of the minor unit (ISO 4217).
+func CurrencyMinorDigits(currency string) int {
+	switch currency {
+	case "JPY", "KRW", "ISK", "CLP", "VND":
+		return 0
+	}
+	return 2
+}
+
+// ToCM converts v in unit to centimetres.
+func ToCM(v float64, unit string) float64 {
+	switch unit {
+	case UnitMM:
+		return v / 10
+	case UnitIN:
+		return v * 2.54
+	}
+	return v
+}
+
+// ---------- medium vocabulary
+
+type MediumTerm struct {
+	Key    string            `json:"key"`
+	Labels map[string]string `json:"labels"` // lang -> label
+	Match  []string          `json:"-"`      // lowercase words recognised in free text; "sculpt*" = word prefix
+}
+
+// Mediums is the controlled vocabulary for ArtworkRevision.MediumKey, most specific first.
+var Mediums = []MediumTerm{
+	{Key: "oil_on_canvas", Labels: map[string]string{"en": "Oil on canvas", "de": "Öl auf Leinwand", "fr": "Huile sur toile"}, Match: []string{"oil on canvas", "öl auf leinwand", "huile sur toile"}},
+	{Key: "acrylic_on_canvas", Labels: map[string]string{"en": "Acrylic on canvas", "de": "Acryl auf Leinwand", "fr": "Acrylique sur toile"}, Match: []string{"acrylic on canvas", "acryl auf leinwand", "acrylique sur toile"}},
+	{Key: "oil", Labels: map[string]string{"en": "Oil", "de": "Öl", "fr": "Huile"}, Match: []string{"oil", "oils", "öl", "ölfarbe", "huile"}},
+	{Key: "acrylic", Labels: map[string]string{"en": "Acrylic", "de": "Acryl", "fr": "Acrylique"}, Match: []string{"acrylic*", "acryl*"}},
+	{Key: "watercolor", Labels: map[string]string{"en": "Watercolor", "de": "Aquarell", "fr": "Aquarelle"}, Match: []string{"watercolo*", "aquarel*"}},
+	{Key: "gouache", Labels: map[string]string{"en": "Gouache", "de": "Gouache", "fr": "Gouache"}, Match: []string{"gouache"}},
+	{Key: "pastel", Labels: map[string]string{"en": "Pastel", "de": "Pastell", "fr": "Pastel"}, Match: []string{"pastel*"}},
+	{Key: "ink", Labels: map[string]string{"en": "Ink", "de": "Tusche", "fr": "Encre"}, Match: []string{"ink", "inks", "tusche", "encre"}},
+	{Key: "charcoal", Labels: map[string]string{"en": "Charcoal", "de": "Kohle", "fr": "Fusain"}, Match: []string{"charcoal", "kohle", "fusain"}},
+	{Key: "pencil", Labels: map[string]string{"en": "Pencil", "de": "Bleistift", "fr": "Crayon"}, Match: []string{"pencil", "graphite", "bleistift", "crayon"}},
+	{Key: "mixed_media", Labels: map[string]string{"en": "Mixed media", "de": "Mischtechnik", "fr": "Technique mixte"}, Match: []string{"mixed", "mischtechnik", "technique mixte"}},
+	{Key: "collage", Labels: map[string]string{"en": "Collage", "de": "Collage", "fr": "Collage"}, Match: []string{"collage*"}},
+	{Key: "print", Labels: map[string]string{"en": "Print", "de": "Druckgrafik", "fr": "Estampe"}, Match: []string{"print*", "etching*", "lithograph*", "screen*", "woodcut*", "radierung*", "druck*", "siebdruck", "estampe*", "gravure*"}},
+	{Key: "photography", Labels: map[string]string{"en": "Photography", "de": "Fotografie", "fr": "Photographie"}, Match: []string{"photo*", "foto*", "pigment*"}},
+	{Key: "digital", Labels: map[string]string{"en": "Digital", "de": "Digital", "fr": "Numérique"}, Match: []string{"digital", "numérique"}},
+	{Key: "sculpture", Labels: map[string]string{"en": "Sculpture", "de": "Skulptur", "fr": "Sculpture"}, Match: []string{"sculpt*", "skulptur*", "bronze", "marble", "marmor"}},
+	{Key: "ceramic", Labels: map[string]string{"en": "Ceramic", "de": "Keramik", "fr": "Céramique"}, Match: []string{"ceramic*", "keramik", "céramique", "porcelain", "porzellan"}},
+	{Key: "textile", Labels: map[string]string{"en": "Textile", "de": "Textil", "fr": "Textile"}, Match: []string{"textil*", "tapestry", "wool"}},
+	{Key: "installation", Labels: map[string]string{"en": "Installation", "de": "Installation", "fr": "Installation"}, Match: []string{"installation*"}},
+	{Key: "video", Labels: map[string]string{"en": "Video", "de": "Video", "fr": "Vidéo"}, Match: []string{"video*", "vidéo*"}},
+}
+
+func FindMedium(key string) (MediumTerm, bool) {
+	for _, m := range Mediums {
+		if m.Key == key {
+			return m, true
+		}
+	}
+	return MediumTerm{}, false
+}
+
+// MediumLabel returns the label of key in lang (fallback English, then the key).
+func MediumLabel(key, lang string) string {
+	m, ok := FindMedium(key)
+	if !ok {
+		return key
+	}
+	if l, ok := m.Labels[BaseLang(lang)]; ok {
+		return l
+	}
+	return m.Labels["en"]
+}
+
+// mediumMatchers are the Match words of Mediums as regexps on word boundaries
+// ("ink" does not match "pink", "oil" not "soil").
+var mediumMatchers = func() [][]*regexp.Regexp {
+	out := make([][]*regexp.Regexp, len(Mediums))
+	for i, m := range Mediums {
+		for _, word := range m.Match {
+			end := `(?:$|[^\pL])`
+			if strings.HasSuffix(word, "*") {
+				word, end = strings.TrimSuffix(word, "*"), ""
+			}
+			out[i] = append(out[i], regexp.MustCompile(`(?:^|[^\pL])`+regexp.QuoteMeta(word)+end))
+		}
+	}
+	return out
+}()
+
+// MatchMediumKey guesses a vocabulary key from free text ("" = no match).
+func MatchMediumKey(text string) string {
+	t := strings.ToLower(text)
+	if strings.TrimSpace(t) == "" {
+		return ""
+	}
+	for i, m := range Mediums {
+		for _, re := range mediumMatchers[i] {
+			if re.MatchString(t) {
+				return m.Key
+			}
+		}
+	}
+	return ""
+}
+
+// ---------- best-effort parsing of the free-text columns
+
+type Dimensions struct {
+	Height *float64
+	Width  *float64
+	Depth  *float64
+	Unit   string
+}
+
+var (
+	dimensionRe      = regexp.MustCompile(`^\s*(\d+(?:[.,]\d+)?)(?:\s*[x×X*]\s*(\d+(?:[.,]\d+)?))?(?:\s*[x×X*]\s*(\d+(?:[.,]\d+)?))?\s*([a-zA-Z"″]*)`)
+	dimensionExactRe = regexp.MustCompile(dimensionRe.String() + `\s*$`)
+)
+
+// ParseDimensions reads "100 x 80", "100×80×4 cm", "39.4 x 31.5 in" (H x W x D).
+// A missing unit means cm, the column always held centimetres.
+func ParseDimensions(s string) (Dimensions, bool) {
+	m := dimensionRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Dimensions{}, false
+	}
+
+	unit := UnitCM
+	switch strings.ToLower(m[4]) {
+	case "", "cm":
+	case "mm":
+		unit = UnitMM
+	case "in", "inch", "inches", `"`, "″":
+		unit = UnitIN
+	default:
+		return Dimensions{}, false
+	}
+
+	d := Dimensions{Unit: unit}
+	for i, dst := range []**float64{&d.Height, &d.Width, &d.Depth} {
+		if m[i+1] == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.ReplaceAll(m[i+1], ",", "."), 64)
+		if err != nil || v <= 0 {
+			return Dimensions{}, false
+		}
+		*dst = &v
+	}
+	return d, true
+}
+
+type PriceInfo struct {
+	AmountMinor *int64
+	Currency    string
+	Visibility  string
+}
+
+var currencySymbols = map[string]string{
+	"€": "EUR", "$": "USD", "£": "GBP", "¥": "JPY",
+}
+
+var amountRe = regexp.MustCompile(`\d[\d.,' ]*`)
+
+var priceOnRequestWords = []string{"request", "anfrage", "demande", "consult", "richiesta", "aanvraag", "p.o.a", "poa"}
+
+// Exact* report whether the Parse* functions read all of s. Only then the
+// structured value says the same as the free text; "ca. 2019" or "100 x 80 cm
+// (framed 110 x 90)" keep their wording when displayed.
+
+func ExactYear(s string) bool {
+	return yearExactRe.MatchString(s)
+}
+
+func ExactDimensions(s string) bool {
+	if !dimensionExactRe.MatchString(s) {
+		return false
+	}
+	_, ok := ParseDimensions(s)
+	return ok
+}
+
+// ExactPrice: nothing but the amount and a currency symbol or code.
+func ExactPrice(s string) bool {
+	t := strings.TrimSpace(s)
+	loc := amountRe.FindStringIndex(t)
+	if loc == nil {
+		return false
+	}
+	rest := t[:loc[0]] + " " + t[loc[1]:]
+	for sym := range currencySymbols {
+		rest = strings.Replace(rest, sym, " ", 1)
+	}
+	for _, word := range strings.Fields(rest) {
+		word = strings.Trim(word, ".,-–")
+		if word != "" && !ValidCurrency(strings.ToUpper(word)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParsePrice reads "1.200 €", "€1,200.50", "USD 900", "auf Anfrage", "NFS".
+func ParsePrice(s string) (PriceInfo, bool) {
+	t := strings.TrimSpace(s)
+	lower := strings.ToLower(t)
+	if t == "" {
+		return PriceInfo{}, false
+	}
+	for _, w := range priceOnRequestWords {
+		if strings.Contains(lower, w) {
+			return PriceInfo{Visibility: PriceOnRequest}, true
+		}
+	}
+	if lower == "nfs" || strings.Contains(lower, "not for sale") || strings.Contains(lower, "unverkäuflich") {
+		return PriceInfo{Visibility: PriceHidden}, true
+	}
+
+	currency := ""
+	for sym, code := range currencySymbols {
+		if strings.Contains(t, sym) {
+			currency = code
+			break
+		}
+	}
+	if currency == "" {
+		for _, word := range strings.Fields(strings.ToUpper(t)) {
+			word = strings.Trim(word, ".,-")
+			if ValidCurrency(word) {
+				currency = word
+				break
+			}
+		}
+	}
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	raw := amountRe.FindString(t)
+	raw = strings.TrimRight(strings.TrimSpace(raw), ".,")
+	if raw == "" {
+		return PriceInfo{}, false
+	}
+	amount, ok := parseAmount(raw)
+	if !ok {
+		return PriceInfo{}, false
+	}
+
+	minor := int64(math.Round(amount * math.Pow10(CurrencyMinorDigits(currency))))
+	return PriceInfo{AmountMinor: &minor, Currency: currency, Visibility: PriceShow}, true
+}
+
+// parseAmount resolves "1.200,50" vs "1,200.50": the last separator followed by
+// 1-2 digits is the decimal one, everything else groups thousands.
+func parseAmount(raw string) (float64, bool) {
+	raw = strings.NewReplacer(" ", "", "'", "").Replace(raw)
+	last := strings.LastIndexAny(raw, ".,")
+	if last >= 0 && len(raw)-last-1 <= 2 {
+		intPart := strings.NewReplacer(".", "", ",", "").Replace(raw[:last])
+		raw = intPart + "." + raw[last+1:]
+	} else {
+		raw = strings.NewReplacer(".", "", ",", "").Replace(raw)
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	return v, err == nil && v >= 0
+}
+
+var (
+	yearRe      = regexp.MustCompile(`\b(1[5-9]\d\d|20\d\d)\b(?:\s*[-–/]\s*(\d{4}|\d{2})\b)?`)
+	yearExactRe = regexp.MustCompile(`^\s*(1[5-9]\d\d|20\d\d)(?:\s*[-–/]\s*(\d{4}|\d{2}))?\s*$`)
+)
+
+// ParseYear reads "2019", "ca. 2019", "2019-2021", "2019–21".
+func ParseYear(s string) (from, to *int, ok bool) {
+	m := yearRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil, nil, false
+	}
+	f, _ := strconv.Atoi(m[1])
+	from = &f
+	if m[2] != "" {
+		t, _ := strconv.Atoi(m[2])
+		if len(m[2]) == 2 {
+			t = f/100*100 + t
+		}
+		if t > f {
+			to = &t
+		}
+	}
+	return from, to, true
+}
+
+// BaseLang maps "de-AT" / "de_at" to "de".
+func BaseLang(lang string) string {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		return lang[:i]
+	}
+	return lang
+}
@@ -0,0 +1,211 @@
+package works
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Display strings for the structured artwork fields, per language.
+// Each shows the free-text column as typed when the structured value is missing
+// or says less than the text (see ExactYear and friends).
+
+type numberFormat struct {
+	decimal     string
+	group       string
+	symbolAfter bool // "1.200 €" vs "€1,200"
+}
+
+var numberFormats = map[string]numberFormat{
+	"en": {decimal: ".", group: ",", symbolAfter: false},
+	"de": {decimal: ",", group: ".", symbolAfter: true},
+	"fr": {decimal: ",", group: " ", symbolAfter: true},
+	"es": {decimal: ",", group: ".", symbolAfter: true},
+	"it": {decimal: ",", group: ".", symbolAfter: true},
+	"nl": {decimal: ",", group: ".", symbolAfter: false},
+	"pt": {decimal: ",", group: ".", symbolAfter: true},
+}
+
+func formatFor(lang string) numberFormat {
+	if f, ok := numberFormats[BaseLang(lang)]; ok {
+		return f
+	}
+	return numberFormats["en"]
+}
+
+var priceOnRequestLabels = map[string]string{
+	"en": "Price on request",
+	"de": "Preis auf Anfrage",
+	"fr": "Prix sur demande",
+	"es": "Precio a consultar",
+	"it": "Prezzo su richiesta",
+	"nl": "Prijs op aanvraag",
+	"pt": "Preço sob consulta",
+}
+
+var currencyDisplay = map[string]string{
+	"EUR": "€", "USD": "$", "GBP": "£", "JPY": "¥",
+}
+
+// YearDisplay renders "2019" or "2019–2021".
+func (r ArtworkRevision) YearDisplay() string {
+	if r.YearFrom == nil || (r.Year != "" && !ExactYear(r.Year)) {
+		return r.Year
+	}
+	s := strconv.Itoa(*r.YearFrom)
+	if r.YearTo != nil && *r.YearTo != *r.YearFrom {
+		s += "–" + strconv.Itoa(*r.YearTo)
+	}
+	return s
+}
+
+// MediumDisplay translates vocabulary media; the artist's own wording
+// ("Oil and gold leaf on linen") is shown as typed.
+func (r ArtworkRevision) MediumDisplay(lang string) string {
+	if r.MediumKey != "" && (r.Medium == "" || r.Medium == MediumLabel(r.MediumKey, "en")) {
+		return MediumLabel(r.MediumKey, lang)
+	}
+	return r.Medium
+}
+
+// SizeDisplay renders "100 × 80 cm"; English adds inches, other languages add
+// centimetres for works measured in inches.
+func (r ArtworkRevision) SizeDisplay(lang string) string {
+	if (r.Height == nil && r.Width == nil && r.Depth == nil) || (r.SizeCM != "" && !ExactDimensions(r.SizeCM)) {
+		return r.SizeCM
+	}
+	unit := r.dimensionUnit()
+	f := formatFor(lang)
+
+	main := joinDimensions(f, unit, 1, r.Height, r.Width, r.Depth)
+	switch {
+	case BaseLang(lang) == "en" && unit != UnitIN:
+		return main + " (" + joinDimensions(f, UnitIN, 1, inches(r.Height, unit), inches(r.Width, unit), inches(r.Depth, unit)) + ")"
+	case BaseLang(lang) != "en" && unit == UnitIN:
+		return main + " (" + joinDimensions(f, UnitCM, 1, cm(r.Height, unit), cm(r.Width, unit), cm(r.Depth, unit)) + ")"
+	}
+	return main
+}
+
+// SizeText is the free text for structured dimensions: "100 × 80 cm", in their
+// own unit only.
+func (r ArtworkRevision) SizeText() string {
+	return joinDimensions(formatFor("en"), r.dimensionUnit(), 1, r.Height, r.Width, r.Depth)
+}
+
+func (r ArtworkRevision) dimensionUnit() string {
+	if r.DimensionUnit == "" {
+		return UnitCM
+	}
+	return r.DimensionUnit
+}
+
+// PriceDisplay renders the price per PriceVisibility; "" when hidden.
+func (r ArtworkRevision) PriceDisplay(lang string) string {
+	switch r.PriceVisibility {
+	case PriceHidden:
+		return ""
+	case PriceOnRequest:
+		if l, ok := priceOnRequestLabels[BaseLang(lang)]; ok {
+			return l
+		}
+		return priceOnRequestLabels["en"]
+	}
+	if r.PriceMinor == nil || (r.Price != "" && !ExactPrice(r.Price)) {
+		return r.Price
+	}
+	return FormatMoney(*r.PriceMinor, r.PriceCurrency, lang)
+}
+
+// FormatMoney renders minor units, e.g. (120050, "EUR", "de") -> "1.200,50 €".
+func FormatMoney(minor int64, currency, lang string) string {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	f := formatFor(lang)
+	digits := CurrencyMinorDigits(currency)
+
+	neg := minor < 0
+	if neg {
+		minor = -minor
+	}
+	scale := int64(math.Pow10(digits))
+	whole, frac := minor/scale, minor%scale
+
+	s := groupThousands(strconv.FormatInt(whole, 10), f.group)
+	if frac != 0 {
+		s += f.decimal + leftPad(strconv.FormatInt(frac, 10), digits)
+	}
+	if neg {
+		s = "-" + s
+	}
+
+	sym, ok := currencyDisplay[currency]
+	if !ok {
+		return s + " " + currency
+	}
+	if f.symbolAfter {
+		return s + " " + sym
+	}
+	return sym + s
+}
+
+func joinDimensions(f numberFormat, unit string, decimals int, values ...*float64) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != nil {
+			parts = append(parts, formatDecimal(*v, decimals, f))
+		}
+	}
+	return strings.Join(parts, " × ") + " " + unit
+}
+
+func inches(v *float64, unit string) *float64 {
+	if v == nil {
+		return nil
+	}
+	in := ToCM(*v, unit) / 2.54
+	return &in
+}
+
+func cm(v *float64, unit string) *float64 {
+	if v == nil {
+		return nil
+	}
+	c := ToCM(*v, unit)
+	return &c
+}
+
+// formatDecimal rounds to decimals and drops a trailing ".0".
+func formatDecimal(v float64, decimals int, f numberFormat) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return strings.Replace(s, ".", f.decimal, 1)
+}
+
+func groupThousands(s, sep string) string {
+	if len(s) <= 3 {
+		return s
+	}
+	var b strings.Builder
+	pre := len(s) % 3
+	if pre > 0 {
+		b.WriteString(s[:pre])
+	}
+	for i := pre; i < len(s); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(s[i : i+3])
+	}
+	return b.String()
+}
+
+func leftPad(s string, n int) string {
+	for len(s) < n {
+		s = "0" + s
+	}
+	return s
+}
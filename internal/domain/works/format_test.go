@@ -0,0 +1,75 @@
+package works
+
+import "testing"
+
+// revisionFromText parses free text the way the API stores it.
+func revisionFromText(year, size, price string) ArtworkRevision {
+	r := ArtworkRevision{Year: year, SizeCM: size, Price: price, PriceVisibility: PriceShow}
+	r.YearFrom, r.YearTo, _ = ParseYear(year)
+	if d, ok := ParseDimensions(size); ok {
+		r.Height, r.Width, r.Depth, r.DimensionUnit = d.Height, d.Width, d.Depth, d.Unit
+	}
+	if p, ok := ParsePrice(price); ok {
+		r.PriceMinor, r.PriceCurrency, r.PriceVisibility = p.AmountMinor, p.Currency, p.Visibility
+	}
+	return r
+}
+
+func TestDisplayKeepsFreeTextUnlessExact(t *testing.T) {
+	tests := []struct {
+		year, size, price string
+		wantYear          string
+		wantSize          string
+		wantPrice         string
+	}{
+		{"2019", "100 x 80", "1.200 €", "2019", "100 × 80 cm", "1.200 €"},
+		{"2019-21", "100x80x4 cm", "USD 900", "2019–2021", "100 × 80 × 4 cm", "900 $"},
+		{"ca. 2019", "100 x 80 cm (framed 110 x 90)", "1200 plus VAT", "ca. 2019", "100 x 80 cm (framed 110 x 90)", "1200 plus VAT"},
+	}
+	for _, tt := range tests {
+		r := revisionFromText(tt.year, tt.size, tt.price)
+		if got := r.YearDisplay(); got != tt.wantYear {
+			t.Errorf("YearDisplay(%q) = %q, want %q", tt.year, got, tt.wantYear)
+		}
+		if got := r.SizeDisplay("de"); got != tt.wantSize {
+			t.Errorf("SizeDisplay(%q) = %q, want %q", tt.size, got, tt.wantSize)
+		}
+		if got := r.PriceDisplay("de"); got != tt.wantPrice {
+			t.Errorf("PriceDisplay(%q) = %q, want %q", tt.price, got, tt.wantPrice)
+		}
+	}
+}
+
+func TestParsePriceCurrency(t *testing.T) {
+	tests := map[string]string{
+		"1200 plus VAT": DefaultCurrency,
+		"CHF 1'200":     "CHF",
+		"£900":          "GBP",
+		"900 abc":       DefaultCurrency,
+	}
+	for in, want := range tests {
+		p, ok := ParsePrice(in)
+		if !ok || p.Currency != want {
+			t.Errorf("ParsePrice(%q) = %+v, %v; want currency %s", in, p, ok, want)
+		}
+	}
+}
+
+func TestMatchMediumKeyWordBoundaries(t *testing.T) {
+	tests := map[string]string{
+		"Oil on canvas":           "oil_on_canvas",
+		"oil and gold leaf":       "oil",
+		"Pink and soil":           "",
+		"Ink on paper":            "ink",
+		"Aquarell auf Papier":     "watercolor",
+		"Screenprint, 20 copies":  "print",
+		"Sculpted wood":           "sculpture",
+		"Acrylique sur toile":     "acrylic_on_canvas",
+		"Mischtechnik auf Papier": "mixed_media",
+	}
+	for in, want := range tests {
+		if got := MatchMediumKey(in); got != want {
+			t.Errorf("MatchMediumKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package works
+
+import (
+	"sort"
+	"strings"
+)
+
+// Full-text search. Each i18n row is stemmed with the Postgres text search
+// configuration of its Lang; the SQL function works_ts_config(lang) (created by
+// a data migration from TextSearchConfigs) does the mapping in indexes and queries.
+
+// TextSearchConfigs maps base languages to Postgres configurations;
+// other languages use SimpleTextSearchConfig (no stemming).
+var TextSearchConfigs = map[string]string{
+	"da": "danish",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"hu": "hungarian",
+	"it": "italian",
+	"nl": "dutch",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"tr": "turkish",
+}
+
+const SimpleTextSearchConfig = "simple"
+
+// Indexed search vectors (GIN expression indexes). Queries must repeat these
+// expressions verbatim, unaliased, for the planner to use the indexes.
+const (
+	ArtworkI18nSearchVector = `(setweight(to_tsvector(works_ts_config(lang), coalesce(title, '')), 'A') || ` +
+		`setweight(to_tsvector(works_ts_config(lang), coalesce(description, '')), 'B') || ` +
+		`setweight(to_tsvector(works_ts_config(lang), coalesce(notes, '')), 'C'))`
+
+	SeriesI18nSearchVector = `(setweight(to_tsvector(works_ts_config(lang), coalesce(title, '')), 'A') || ` +
+		`setweight(to_tsvector(works_ts_config(lang), coalesce(description_serie, '')), 'B') || ` +
+		`setweight(to_tsvector(works_ts_config(lang), coalesce(year, '')), 'C'))`
+
+	// medium and year are free text in no particular language
+	ArtworkFieldsSearchVector = `(setweight(to_tsvector('simple', coalesce(medium, '')), 'B') || ` +
+		`setweight(to_tsvector('simple', coalesce(year, '')), 'C'))`
+)
+
+// TextSearchConfig returns the configuration for lang ("de-AT" -> "german").
+func TextSearchConfig(lang string) string {
+	if cfg, ok := TextSearchConfigs[BaseLang(lang)]; ok {
+		return cfg
+	}
+	return SimpleTextSearchConfig
+}
+
+// TextSearchConfigNames lists every configuration in use, sorted, incl. simple.
+func TextSearchConfigNames() []string {
+	names := []string{SimpleTextSearchConfig}
+	for _, cfg := range TextSearchConfigs {
+		names = append(names, cfg)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TextSearchConfigFunctionSQL creates works_ts_config(lang). It is IMMUTABLE so
+// it can be used in index expressions; changing the mapping needs a reindex.
+func TextSearchConfigFunctionSQL() string {
+	langs := make([]string, 0, len(TextSearchConfigs))
+	for lang := range TextSearchConfigs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	var b strings.Builder
+	b.WriteString("CREATE OR REPLACE FUNCTION works_ts_config(lang text) RETURNS regconfig\n")
+	b.WriteString("LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$\n")
+	b.WriteString("\tSELECT CASE lower(split_part(replace(lang, '_', '-'), '-', 1))\n")
+	for _, lang := range langs {
+		b.WriteString("\t\tWHEN '" + lang + "' THEN '" + TextSearchConfigs[lang] + "'::regconfig\n")
+	}
+	b.WriteString("\t\tELSE '" + SimpleTextSearchConfig + "'::regconfig\n\tEND\n$$")
+	return b.String()
+}
@@ -2,6 +2,8 @@ package works
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 const (
@@ -17,6 +19,9 @@ type Series struct {
 
 	IDLocked bool `gorm:"not null;default:false" json:"id_locked"`
 
+	// position on the site; ties fall back to newest first (seriesOrder)
+	SortIndex int `gorm:"not null;default:0;index" json:"sort_index"`
+
 	PublishedRevisionID *string         `gorm:"type:uuid;index" json:"-"`
 	DraftRevisionID     *string         `gorm:"type:uuid;index" json:"-"`
 	DraftRevision       *SeriesRevision `gorm:"foreignKey:DraftRevisionID"`
@@ -24,6 +29,16 @@ type Series struct {
 
 	Items []Artwork `gorm:"foreignKey:SeriesID;constraint:OnDelete:CASCADE;" json:"items,omitempty"`
 
+	// tags and categories (join table series_terms), inherited by the items in filters
+	Terms []Term `gorm:"many2many:series_terms;" json:"terms,omitempty"`
+
+	// pending schedules, applied once by the publish scheduler and then cleared
+	PublishAt   *time.Time `gorm:"index" json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `gorm:"index" json:"unpublish_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// soft delete: set = in trash (GET /trash), hard-deleted after the retention window
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
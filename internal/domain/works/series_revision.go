@@ -14,6 +14,9 @@ type SeriesRevision struct {
 
 	I18n []SeriesI18nRevision `gorm:"constraint:OnDelete:CASCADE;" json:"i18n,omitempty"`
 
+	// set when the revision went live; published revisions are immutable history
+	PublishedAt *time.Time `gorm:"index" json:"published_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,64 @@
+package works
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	TermTag      = "tag"
+	TermCategory = "category"
+)
+
+func ValidTermKind(k string) bool {
+	return k == TermTag || k == TermCategory
+}
+
+// Term is a user-defined tag or category. Terms attach to the identity of
+// artworks and series (not to revisions); a term on a series applies to all
+// of its artworks when filtering.
+type Term struct {
+	ID     string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID uint   `gorm:"not null;uniqueIndex:idx_terms_user_kind_slug,priority:1" json:"-"`
+
+	Kind string `gorm:"type:text;not null;uniqueIndex:idx_terms_user_kind_slug,priority:2" json:"kind"`
+	Slug string `gorm:"type:text;not null;uniqueIndex:idx_terms_user_kind_slug,priority:3" json:"slug"` // stable filter value
+
+	SortIndex int `gorm:"not null;default:0" json:"sort_index"`
+
+	I18n []TermI18n `gorm:"constraint:OnDelete:CASCADE;" json:"i18n,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TermI18n struct {
+	TermID string `gorm:"type:uuid;primaryKey"`
+	Lang   string `gorm:"primaryKey"`
+	Label  string `gorm:"not null" json:"label"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ArtworkTerm / SeriesTerm are the join tables of Artwork.Terms / Series.Terms;
+// term_id is indexed on its own for the filter and facet queries.
+type ArtworkTerm struct {
+	ArtworkID string `gorm:"type:uuid;primaryKey"`
+	TermID    string `gorm:"type:uuid;primaryKey;index"`
+}
+
+type SeriesTerm struct {
+	SeriesID string `gorm:"type:uuid;primaryKey"`
+	TermID   string `gorm:"type:uuid;primaryKey;index"`
+}
+
+var slugInvalidRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify turns a label into a slug: "Works on Paper" -> "works-on-paper".
+func Slugify(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss", "é", "e", "è", "e", "à", "a", "ç", "c").Replace(s)
+	return strings.Trim(slugInvalidRe.ReplaceAllString(s, "-"), "-")
+}
@@ -0,0 +1,84 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg" // also registers the decoder
+	_ "image/png"
+	"io"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+/*
+	Image processing helpers (pure Go, no cgo)
+	- decode JPEG / PNG / WebP
+	- downscale with Catmull-Rom
+	- encode WebP (VP8L, lossless), JPEG (for PDFs)
+*/
+
+// MaxPixels caps width*height of images we decode: a decoded image takes ~4
+// bytes per pixel, and a small, highly compressed file can declare huge sizes.
+const MaxPixels = 50_000_000
+
+// errors of CheckPixels (and Decode); test with errors.Is
+var (
+	ErrTooManyPixels     = errors.New("image too large")
+	ErrInvalidDimensions = errors.New("invalid image dimensions")
+)
+
+// DecodeConfig reads only the header (dimensions + format).
+func DecodeConfig(data []byte) (image.Config, string, error) {
+	return image.DecodeConfig(bytes.NewReader(data))
+}
+
+// CheckPixels rejects dimensions above MaxPixels.
+func CheckPixels(cfg image.Config) error {
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return ErrInvalidDimensions
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return fmt.Errorf("%w (%dx%d)", ErrTooManyPixels, cfg.Width, cfg.Height)
+	}
+	return nil
+}
+
+// Decode checks the header against MaxPixels before decoding the pixels.
+func Decode(data []byte) (image.Image, string, error) {
+	cfg, _, err := DecodeConfig(data)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := CheckPixels(cfg); err != nil {
+		return nil, "", err
+	}
+	return image.Decode(bytes.NewReader(data))
+}
+
+// ResizeToWidth scales img to width keeping the aspect ratio. Never upscales.
+func ResizeToWidth(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if width <= 0 || width >= b.Dx() {
+		return img
+	}
+	height := int(float64(b.Dy()) * float64(width) / float64(b.Dx()))
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+func EncodeWebP(w io.Writer, img image.Image) error {
+	return nativewebp.Encode(w, img, nil)
+}
+
+func EncodeJPEG(w io.Writer, img image.Image, quality int) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+/*
+	Object storage
+	--------------
+	- Stores uploaded originals and generated derivatives under
+	  slash-separated keys, e.g. "users/12/originals/<hex>.jpg"
+	- Backends: local filesystem (UPLOAD_DIR) or S3-compatible (MinIO, AWS, ...)
+	- No DB access here; media rows keep the key in media.Image.StorageKey
+*/
+
+var ErrNotFound = errors.New("storage: object not found")
+
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL stored in media.Image paths.
+	URL(key string) string
+}
+
+var Store Storage
+
+// InitStorage picks the backend from STORAGE_DRIVER ("local" default, or "s3").
+func InitStorage() {
+	driver := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_DRIVER")))
+
+	switch driver {
+	case "", "local":
+		root := getEnv("UPLOAD_DIR", "./uploads")
+		s, err := NewLocalStorage(root, getEnv("UPLOAD_PUBLIC_URL", "/uploads"))
+		if err != nil {
+			log.Fatal("❌ Failed to init local storage:", err)
+		}
+		Store = s
+	case "s3":
+		s, err := NewS3Storage(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    getEnv("S3_REGION", "us-east-1"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PublicURL: os.Getenv("S3_PUBLIC_URL"),
+		})
+		if err != nil {
+			log.Fatal("❌ Failed to init S3 storage:", err)
+		}
+		Store = s
+	default:
+		log.Fatalf("❌ Unknown STORAGE_DRIVER: %s", driver)
+	}
+}
+
+// validKey rejects empty keys and path traversal.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func getEnv(key string, fallback string) string {
+	if value, exists := os.LookupEnv(key); exists && value != "" {
+		return value
+	}
+	return fallback
+}